@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/repository"
+	"trailblazer/internal/service"
+)
+
+func InitLogger() *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return logger
+}
+
+func main() {
+	logger := InitLogger()
+	slog.SetDefault(logger)
+
+	configPath := flag.String("c", "configs/config.yml", "The path to the configuration file")
+	region := flag.String("region", "", "Region to export, all configured regions if empty")
+	output := flag.String("o", ".", "Directory to write bundles to")
+	flag.Parse()
+	cfg, err := config.New(*configPath)
+	if err != nil {
+		slog.Error(fmt.Sprintf("error to parse config: %v", err))
+		return
+	}
+	repo, err := repository.NewPostgresRepository(context.Background(), cfg.DatabaseConfig)
+	if err != nil {
+		slog.Error(fmt.Sprintf("failed to initialize DB: %v", err))
+		return
+	}
+	bundles := service.NewBundleService(repo.Landmark, repo.Sync, cfg.BundleConfig)
+
+	regions := bundles.Regions()
+	if *region != "" {
+		regions = []string{*region}
+	}
+	if err := os.MkdirAll(*output, 0o755); err != nil {
+		slog.Error(fmt.Sprintf("failed to create output dir: %v", err))
+		return
+	}
+	for _, r := range regions {
+		manifest, archive, err := bundles.BuildBundle(r)
+		if err != nil {
+			slog.Error(fmt.Sprintf("failed to build bundle %s: %v", r, err))
+			continue
+		}
+		path := filepath.Join(*output, fmt.Sprintf("%s-%s.zip", r, manifest.Version))
+		if err := os.WriteFile(path, archive, 0o644); err != nil {
+			slog.Error(fmt.Sprintf("failed to write bundle %s: %v", r, err))
+			continue
+		}
+		slog.Info(fmt.Sprintf("bundle %s: %d files, %d bytes -> %s", r, len(manifest.Files), len(archive), path))
+	}
+}
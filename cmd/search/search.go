@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/handler"
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Сервер поиска для разработки: индекс строится в памяти по landmarks.json
+// офлайн-пакета, база данных и PostGIS не нужны. Синонимы не подставляются.
+
+func InitLogger() *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return logger
+}
+
+func main() {
+	logger := InitLogger()
+	slog.SetDefault(logger)
+
+	configPath := flag.String("c", "configs/config.yml", "The path to the configuration file")
+	source := flag.String("source", "", "Bundle zip or landmarks.json to index, search.source if empty")
+	flag.Parse()
+	cfg, err := config.New(*configPath)
+	if err != nil {
+		slog.Error(fmt.Sprintf("error to parse config: %v", err))
+		return
+	}
+	searchCfg := cfg.SearchConfig
+	searchCfg.Backend = "memory"
+	if *source != "" {
+		searchCfg.Source = *source
+	}
+	if searchCfg.Source == "" {
+		slog.Error("search source must be set with -source or search.source")
+		return
+	}
+	searcher, err := service.NewSearcher(searchCfg, nil)
+	if err != nil {
+		slog.Error(fmt.Sprintf("failed to build search index: %v", err))
+		return
+	}
+
+	app := fiber.New()
+	app.Get("/api/search", func(ctx *fiber.Ctx) error {
+		origin, err := handler.QueryLocation(ctx)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		landmarks, err := searcher.Search([]string{ctx.Query("q", "1")}, origin)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return ctx.JSON(landmarks)
+	})
+	if err := app.Listen(":" + cfg.HostConfig.Port); err != nil {
+		slog.Error(fmt.Sprintf("error starting server: %v", err))
+	}
+}
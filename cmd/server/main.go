@@ -10,7 +10,6 @@ import (
 	"syscall"
 	"time"
 
-	api2 "trailblazer/internal/api"
 	"trailblazer/internal/config"
 	"trailblazer/internal/handler"
 	"trailblazer/internal/models"
@@ -55,14 +54,15 @@ func main() {
 
 	hashUtil := utils.NewBcryptHasher()
 	ctx := context.Background()
-	api := api2.NewWeatherClient(cfg.WeatherConfig)
 	slog.Info("initializing repository")
 	services := service.NewService(ctx, repo, tokenMaker, hashUtil, *cfg)
 	slog.Info("initializing services")
-	handlers := handler.NewHandler(services, *api, hashUtil, tokenMaker)
+	handlers := handler.NewHandler(services, hashUtil, tokenMaker)
 	app := fiber.New()
 
 	handlers.InitRoutes(app)
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	services.JobService.StartJobs(jobsCtx)
 	go func() {
 		if err := app.Listen(":" + cfg.HostConfig.Port); err != nil {
 			slog.Warn("error starting server", err)
@@ -90,6 +90,7 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	stopJobs()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := app.Shutdown(); err != nil {
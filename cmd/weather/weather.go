@@ -6,12 +6,13 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"time"
+	"os/signal"
+	"syscall"
 
-	api2 "trailblazer/internal/api"
+	"trailblazer/internal/api"
 	"trailblazer/internal/config"
-	"trailblazer/internal/models"
 	"trailblazer/internal/repository"
+	"trailblazer/internal/service"
 )
 
 func InitLogger() *slog.Logger {
@@ -29,10 +30,10 @@ func main() {
 	if err != nil {
 		slog.Error(fmt.Sprintf("error to parse config: %v", err))
 	}
-	api := api2.NewWeatherClient(cfg.WeatherConfig)
-
+	provider, err := api.NewWeatherProvider(cfg.WeatherConfig)
 	if err != nil {
-		slog.Error(fmt.Sprintf("error to get location: %v", err))
+		slog.Error(fmt.Sprintf("error to create weather provider: %v", err))
+		return
 	}
 	//fmt.Println(res)
 	var repo *repository.Repository
@@ -42,44 +43,19 @@ func main() {
 		return
 	}
 	slog.Info("initializing repository")
+	weather := service.NewWeatherService(repo.Weather, provider, cfg.WeatherConfig)
+	// Регулярное обновление выполняет задача weather сервера, здесь - один запуск
+	// вручную, например после загрузки новых достопримечательностей.
 	landmarks, err := repo.Landmark.GetLandmarks(-1, nil)
 	if err != nil {
-		slog.Warn("failed to get landmarks: ", err)
-		return
-	}
-
-	ticker := time.NewTicker(8 * time.Hour).C
-
-	for _, landmark := range landmarks {
-		slog.Info(fmt.Sprintf("%d-%s", landmark.ID, landmark.Name))
-		res, err := api.WeatherAt(models.Location{
-			Lng: landmark.Lng,
-			Lat: landmark.Lat,
-		})
-		if err != nil {
-			slog.Warn("failed to get weather at: ", landmark, err)
-			continue
-		}
-		repo.Weather.SetWeather(landmark.ID, res)
-
+		slog.Error(fmt.Sprintf("failed to get landmarks: %v", err))
+		os.Exit(1)
 	}
-	slog.Info("Цикл завершен")
-	for _ = range ticker {
-		for _, landmark := range landmarks {
-			slog.Info(fmt.Sprintf("%d-%s", landmark.ID, landmark.Name))
-
-			res, err := api.WeatherAt(models.Location{
-				Lng: landmark.Lng,
-				Lat: landmark.Lat,
-			})
-			if err != nil {
-				slog.Warn("failed to get weather at: ", landmark, err)
-				continue
-			}
-			repo.Weather.SetWeather(landmark.ID, res)
-
-		}
-		slog.Info("Цикл завершен")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if _, err := weather.Refresh(ctx, landmarks); err != nil {
+		slog.Error(fmt.Sprintf("weather refresh failed: %v", err))
+		stop()
+		os.Exit(1)
 	}
-
 }
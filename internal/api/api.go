@@ -1,60 +1,35 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"strings"
 
 	"trailblazer/internal/config"
 	"trailblazer/internal/models"
 )
 
-type WeatherAPI struct {
-	baseURL string
-	token   string
-	Client  *http.Client
-	lang    string
-}
+// Поставщики прогноза погоды, доступные в weather.provider.
+const (
+	ProviderOpenWeatherMap = "openweathermap"
+	ProviderOpenMeteo      = "openmeteo"
+)
 
-func NewWeatherClient(cfg config.WeatherConfig) *WeatherAPI {
-	return &WeatherAPI{
-		baseURL: cfg.WeatherUrl,
-		token:   cfg.ApiKey,
-		Client:  http.DefaultClient,
-		lang:    cfg.Language,
-	}
+// WeatherProvider получает прогноз погоды в точке.
+type WeatherProvider interface {
+	Name() string
+	Forecast(ctx context.Context, loc models.Location) (models.LocationForecast, error)
 }
 
-func (c *WeatherAPI) WeatherAt(loc models.Location) (models.WeatherForecast, error) {
-	params := url.Values{}
-	params.Set("lat", fmt.Sprintf("%f", loc.Lat))
-	params.Set("lon", fmt.Sprintf("%f", loc.Lng))
-	params.Set("units", "metric")
-	params.Set("lang", c.lang)
-	params.Set("appid", c.token)
-	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/data/2.5/forecast?"+params.Encode(), nil)
-	if err != nil {
-		return models.WeatherForecast{}, err
-	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return models.WeatherForecast{}, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return models.WeatherForecast{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-	var weatherResponse models.WeatherForecast
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return models.WeatherForecast{}, err
-	}
-	if err := json.Unmarshal(body, &weatherResponse); err != nil {
-		return models.WeatherForecast{}, err
+// NewWeatherProvider создаёт клиента поставщика, указанного в weather.provider.
+// По умолчанию используется OpenWeatherMap.
+func NewWeatherProvider(cfg config.WeatherConfig) (WeatherProvider, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "", ProviderOpenWeatherMap, "owm":
+		return NewOpenWeatherMap(cfg), nil
+	case ProviderOpenMeteo, "open-meteo":
+		return NewOpenMeteo(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown weather provider: %s", cfg.Provider)
 	}
-	return weatherResponse, nil
 }
@@ -0,0 +1,315 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"trailblazer/internal/config"
+)
+
+// Значения по умолчанию для настроек weather.*, которые не заданы.
+const (
+	DefaultWeatherTimeout   = 10 * time.Second
+	DefaultRetryBaseDelay   = time.Second
+	DefaultRetryMaxDelay    = 30 * time.Second
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = time.Minute
+)
+
+var (
+	// ErrRateLimited - поставщик ответил 429, квота исчерпана.
+	ErrRateLimited = errors.New("weather provider rate limit exceeded")
+	// ErrUnauthorized - поставщик отклонил ключ API.
+	ErrUnauthorized = errors.New("weather provider rejected credentials")
+	// ErrUnavailable - сетевая ошибка, тайм-аут или ответ 5xx.
+	ErrUnavailable = errors.New("weather provider unavailable")
+	// ErrCircuitOpen - запрос не отправлен: поставщик недавно много раз подряд отвечал ошибкой.
+	ErrCircuitOpen = errors.New("weather provider circuit open")
+	// ErrInvalidResponse - ответ поставщика не удалось разобрать.
+	ErrInvalidResponse = errors.New("invalid weather provider response")
+)
+
+// StatusError - ответ поставщика с кодом, отличным от 200.
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	// RetryAfter - значение заголовка Retry-After, если он был.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status code: %d", e.Provider, e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode >= 500:
+		return ErrUnavailable
+	}
+	return nil
+}
+
+func (e *StatusError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// ResilientClient - HTTP-клиент поставщиков погоды. Ограничивает частоту запросов,
+// повторяет запросы при 429, 5xx и сетевых ошибках с экспоненциальной задержкой
+// и прекращает обращения к поставщику, если тот много раз подряд недоступен.
+type ResilientClient struct {
+	provider   string
+	client     *http.Client
+	limiter    *rateLimiter
+	breaker    *circuitBreaker
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func NewResilientClient(provider string, cfg config.WeatherConfig) *ResilientClient {
+	c := &ResilientClient{
+		provider:   provider,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		limiter:    newRateLimiter(cfg.RateLimit),
+		breaker:    newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		maxRetries: max(cfg.MaxRetries, 0),
+		baseDelay:  cfg.RetryBaseDelay,
+		maxDelay:   cfg.RetryMaxDelay,
+	}
+	if c.client.Timeout <= 0 {
+		c.client.Timeout = DefaultWeatherTimeout
+	}
+	if c.baseDelay <= 0 {
+		c.baseDelay = DefaultRetryBaseDelay
+	}
+	if c.maxDelay <= 0 {
+		c.maxDelay = DefaultRetryMaxDelay
+	}
+	return c
+}
+
+// Get выполняет GET-запрос и возвращает тело ответа 200. Отмена ctx прерывает
+// и сам запрос, и ожидание перед повтором.
+func (c *ResilientClient) Get(ctx context.Context, url string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.backoff(attempt, lastErr)
+			if delay < 0 {
+				break
+			}
+			slog.Warn(fmt.Sprintf("%s: retrying in %s after error: %v", c.provider, delay, lastErr))
+			if err := sleep(ctx, delay); err != nil {
+				return nil, fmt.Errorf("%s: %w", c.provider, err)
+			}
+		}
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.provider, err)
+		}
+		if !c.breaker.Allow() {
+			return nil, fmt.Errorf("%s: %w", c.provider, ErrCircuitOpen)
+		}
+
+		body, err := c.do(ctx, url)
+		if err == nil {
+			c.breaker.Success()
+			return body, nil
+		}
+		if ctx.Err() != nil {
+			// Отмена вызывающим не говорит о доступности поставщика.
+			c.breaker.Cancel()
+			return nil, fmt.Errorf("%s: %w", c.provider, ctx.Err())
+		}
+		lastErr = err
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			if statusErr.StatusCode == http.StatusTooManyRequests {
+				// Поставщик доступен, поэтому 429 не считается отказом.
+				c.breaker.Success()
+			} else if statusErr.retryable() {
+				c.breaker.Failure()
+			} else {
+				c.breaker.Success()
+				return nil, err
+			}
+			continue
+		}
+		c.breaker.Failure()
+	}
+	return nil, lastErr
+}
+
+func (c *ResilientClient) do(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", c.provider, ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{
+			Provider:   c.provider,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", c.provider, ErrUnavailable, err)
+	}
+	return body, nil
+}
+
+// backoff возвращает задержку перед повтором номер attempt: случайную величину
+// из [d/2, d], где d = baseDelay*2^(attempt-1), но не больше maxDelay. Если
+// поставщик прислал Retry-After, ждём не меньше указанного; если это дольше
+// maxDelay, повтор не имеет смысла и возвращается -1.
+func (c *ResilientClient) backoff(attempt int, lastErr error) time.Duration {
+	delay := min(c.baseDelay<<(attempt-1), c.maxDelay)
+	if delay <= 0 {
+		delay = c.maxDelay
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+	var statusErr *StatusError
+	if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > 0 {
+		if statusErr.RetryAfter > c.maxDelay {
+			return -1
+		}
+		delay = max(delay, statusErr.RetryAfter)
+	}
+	return delay
+}
+
+// sleep ждёт d или отмены ctx, смотря что наступит раньше.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter разбирает Retry-After в секундах или в формате HTTP-даты.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// rateLimiter равномерно распределяет запросы: не больше perMinute в минуту.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perMinute float64) *rateLimiter {
+	if perMinute <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Minute) / perMinute)}
+}
+
+// Wait блокирует вызывающего до момента, когда запрос можно отправить, или до отмены ctx.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l.interval <= 0 {
+		return ctx.Err()
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+	return sleep(ctx, wait)
+}
+
+// circuitBreaker после threshold ошибок подряд отклоняет запросы в течение
+// cooldown, затем пропускает один пробный запрос. Успешный запрос закрывает
+// выключатель, неудачный снова размыкает его.
+type circuitBreaker struct {
+	mu          sync.Mutex
+	threshold   int
+	cooldown    time.Duration
+	failures    int
+	openedUntil time.Time
+	probing     bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = DefaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || time.Now().Before(b.openedUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openedUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// Cancel отменяет пробный запрос, не меняя счётчик ошибок.
+func (b *circuitBreaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
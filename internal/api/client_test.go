@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"trailblazer/internal/config"
+)
+
+func TestResilientClientCancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		cfg     config.WeatherConfig
+	}{
+		{
+			name:   "backoff after 5xx",
+			status: http.StatusServiceUnavailable,
+			cfg:    config.WeatherConfig{MaxRetries: 3, RetryBaseDelay: time.Minute, RetryMaxDelay: time.Hour},
+		},
+		{
+			name:    "retry-after on 429",
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{"Retry-After": "600"},
+			cfg:     config.WeatherConfig{MaxRetries: 3, RetryBaseDelay: time.Millisecond, RetryMaxDelay: time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			started := time.Now()
+			_, err := NewResilientClient("test", tt.cfg).Get(ctx, server.URL)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Get() error = %v, want context.DeadlineExceeded", err)
+			}
+			if elapsed := time.Since(started); elapsed > time.Second {
+				t.Errorf("Get() returned after %s, want prompt return on cancel", elapsed)
+			}
+			if n := calls.Load(); n != 1 {
+				t.Errorf("provider called %d times, want 1", n)
+			}
+		})
+	}
+}
+
+func TestResilientClientRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewResilientClient("test", config.WeatherConfig{MaxRetries: 3, RetryBaseDelay: time.Millisecond, RetryMaxDelay: 10 * time.Millisecond})
+	body, err := client.Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(body) != "ok" || calls.Load() != 3 {
+		t.Errorf("Get() = %q after %d calls, want \"ok\" after 3", body, calls.Load())
+	}
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+)
+
+// openMeteoSlotHours - длина интервала прогноза. Почасовой прогноз Open-Meteo
+// сводится к 3-часовым интервалам, как у OpenWeatherMap.
+const openMeteoSlotHours = 3
+
+const openMeteoHourly = "temperature_2m,apparent_temperature,relative_humidity_2m,pressure_msl," +
+	"precipitation_probability,precipitation,cloud_cover,wind_speed_10m,wind_direction_10m," +
+	"wind_gusts_10m,visibility,weather_code,is_day"
+
+// OpenMeteo - клиент API Open-Meteo (эндпоинт /v1/forecast). Ключ не требуется.
+type OpenMeteo struct {
+	baseURL string
+	client  *ResilientClient
+	lang    string
+}
+
+func NewOpenMeteo(cfg config.WeatherConfig) *OpenMeteo {
+	return &OpenMeteo{
+		baseURL: strings.TrimSuffix(cfg.OpenMeteoURL, "/"),
+		client:  NewResilientClient(ProviderOpenMeteo, cfg),
+		lang:    strings.ToLower(cfg.Language),
+	}
+}
+
+type openMeteoResponse struct {
+	UTCOffsetSeconds int `json:"utc_offset_seconds"`
+	Hourly           struct {
+		Time                     []int64    `json:"time"`
+		Temperature2m            []*float64 `json:"temperature_2m"`
+		ApparentTemperature      []*float64 `json:"apparent_temperature"`
+		RelativeHumidity2m       []*float64 `json:"relative_humidity_2m"`
+		PressureMSL              []*float64 `json:"pressure_msl"`
+		PrecipitationProbability []*float64 `json:"precipitation_probability"`
+		Precipitation            []*float64 `json:"precipitation"`
+		CloudCover               []*float64 `json:"cloud_cover"`
+		WindSpeed10m             []*float64 `json:"wind_speed_10m"`
+		WindDirection10m         []*float64 `json:"wind_direction_10m"`
+		WindGusts10m             []*float64 `json:"wind_gusts_10m"`
+		Visibility               []*float64 `json:"visibility"`
+		WeatherCode              []*float64 `json:"weather_code"`
+		IsDay                    []*float64 `json:"is_day"`
+	} `json:"hourly"`
+	Daily struct {
+		Sunrise []int64 `json:"sunrise"`
+		Sunset  []int64 `json:"sunset"`
+	} `json:"daily"`
+}
+
+func (c *OpenMeteo) Name() string {
+	return ProviderOpenMeteo
+}
+
+func (c *OpenMeteo) Forecast(ctx context.Context, loc models.Location) (models.LocationForecast, error) {
+	params := url.Values{}
+	params.Set("latitude", fmt.Sprintf("%f", loc.Lat))
+	params.Set("longitude", fmt.Sprintf("%f", loc.Lng))
+	params.Set("hourly", openMeteoHourly)
+	params.Set("daily", "sunrise,sunset")
+	params.Set("wind_speed_unit", "ms")
+	params.Set("timeformat", "unixtime")
+	params.Set("timezone", "auto")
+	params.Set("forecast_days", "5")
+	body, err := c.client.Get(ctx, c.baseURL+"/v1/forecast?"+params.Encode())
+	if err != nil {
+		return models.LocationForecast{}, err
+	}
+	var raw openMeteoResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return models.LocationForecast{}, fmt.Errorf("%s: %w: %v", c.Name(), ErrInvalidResponse, err)
+	}
+	return c.convert(raw), nil
+}
+
+// convert сводит почасовой прогноз к интервалам openMeteoSlotHours, начинающимся
+// в часы UTC, кратные длине интервала. Осадки суммируются, для вероятности
+// осадков, порывов ветра и кода погоды (чем больше код WMO, тем сильнее явление)
+// берётся максимум, остальные значения - на начало интервала.
+func (c *OpenMeteo) convert(raw openMeteoResponse) models.LocationForecast {
+	forecast := models.LocationForecast{
+		Provider: c.Name(),
+		Timezone: raw.UTCOffsetSeconds,
+		Slots:    make([]models.ForecastSlot, 0),
+	}
+	if len(raw.Daily.Sunrise) > 0 {
+		forecast.Sunrise = time.Unix(raw.Daily.Sunrise[0], 0)
+	}
+	if len(raw.Daily.Sunset) > 0 {
+		forecast.Sunset = time.Unix(raw.Daily.Sunset[0], 0)
+	}
+	h := raw.Hourly
+	for i, ts := range h.Time {
+		date := time.Unix(ts, 0).UTC()
+		if date.Hour()%openMeteoSlotHours != 0 {
+			continue
+		}
+		code := 0
+		for j := i; j < i+openMeteoSlotHours && j < len(h.Time); j++ {
+			code = max(code, int(value(h.WeatherCode, j)))
+		}
+		pod := "n"
+		if value(h.IsDay, i) > 0 {
+			pod = "d"
+		}
+		slot := models.ForecastSlot{
+			Date:        date,
+			Temperature: value(h.Temperature2m, i),
+			FeelsLike:   value(h.ApparentTemperature, i),
+			Humidity:    int(value(h.RelativeHumidity2m, i)),
+			Pressure:    int(value(h.PressureMSL, i) + 0.5),
+			Clouds:      int(value(h.CloudCover, i)),
+			WindSpeed:   value(h.WindSpeed10m, i),
+			WindDegree:  int(value(h.WindDirection10m, i)),
+			Visibility:  int(value(h.Visibility, i)),
+			Description: wmoDescription(code, c.lang),
+			Icon:        wmoIcon(code) + pod,
+			Pod:         pod,
+		}
+		for j := i; j < i+openMeteoSlotHours && j < len(h.Time); j++ {
+			slot.Rain += value(h.Precipitation, j)
+			slot.Pop = max(slot.Pop, value(h.PrecipitationProbability, j)/100)
+			slot.WindGust = max(slot.WindGust, value(h.WindGusts10m, j))
+		}
+		forecast.Slots = append(forecast.Slots, slot)
+	}
+	return forecast
+}
+
+// value возвращает i-е значение ряда или 0, если значение отсутствует.
+func value(series []*float64, i int) float64 {
+	if i >= len(series) || series[i] == nil {
+		return 0
+	}
+	return *series[i]
+}
+
+// wmoConditions - описания кодов погоды WMO и соответствующие иконки OpenWeatherMap.
+var wmoConditions = map[int]struct{ ru, en, icon string }{
+	0:  {"ясно", "clear sky", "01"},
+	1:  {"преимущественно ясно", "mainly clear", "02"},
+	2:  {"переменная облачность", "partly cloudy", "03"},
+	3:  {"пасмурно", "overcast", "04"},
+	45: {"туман", "fog", "50"},
+	48: {"изморозь", "depositing rime fog", "50"},
+	51: {"слабая морось", "light drizzle", "09"},
+	53: {"морось", "drizzle", "09"},
+	55: {"сильная морось", "dense drizzle", "09"},
+	56: {"ледяная морось", "freezing drizzle", "09"},
+	57: {"сильная ледяная морось", "dense freezing drizzle", "09"},
+	61: {"небольшой дождь", "light rain", "10"},
+	63: {"дождь", "moderate rain", "10"},
+	65: {"сильный дождь", "heavy rain", "10"},
+	66: {"ледяной дождь", "freezing rain", "13"},
+	67: {"сильный ледяной дождь", "heavy freezing rain", "13"},
+	71: {"небольшой снег", "light snow", "13"},
+	73: {"снег", "moderate snow", "13"},
+	75: {"сильный снег", "heavy snow", "13"},
+	77: {"снежные зёрна", "snow grains", "13"},
+	80: {"небольшой ливень", "light shower rain", "09"},
+	81: {"ливень", "shower rain", "09"},
+	82: {"сильный ливень", "violent shower rain", "09"},
+	85: {"небольшой снегопад", "light snow showers", "13"},
+	86: {"снегопад", "heavy snow showers", "13"},
+	95: {"гроза", "thunderstorm", "11"},
+	96: {"гроза с градом", "thunderstorm with hail", "11"},
+	99: {"гроза с сильным градом", "thunderstorm with heavy hail", "11"},
+}
+
+func wmoDescription(code int, lang string) string {
+	condition, ok := wmoConditions[code]
+	if !ok {
+		return ""
+	}
+	if strings.HasPrefix(lang, "ru") {
+		return condition.ru
+	}
+	return condition.en
+}
+
+func wmoIcon(code int) string {
+	if condition, ok := wmoConditions[code]; ok {
+		return condition.icon
+	}
+	return "03"
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+)
+
+// OpenWeatherMap - клиент API OpenWeatherMap (эндпоинт /data/2.5/forecast).
+type OpenWeatherMap struct {
+	baseURL string
+	token   string
+	client  *ResilientClient
+	lang    string
+}
+
+func NewOpenWeatherMap(cfg config.WeatherConfig) *OpenWeatherMap {
+	return &OpenWeatherMap{
+		baseURL: cfg.WeatherUrl,
+		token:   cfg.ApiKey,
+		client:  NewResilientClient(ProviderOpenWeatherMap, cfg),
+		lang:    cfg.Language,
+	}
+}
+
+func (c *OpenWeatherMap) Name() string {
+	return ProviderOpenWeatherMap
+}
+
+func (c *OpenWeatherMap) Forecast(ctx context.Context, loc models.Location) (models.LocationForecast, error) {
+	raw, err := c.WeatherAt(ctx, loc)
+	if err != nil {
+		return models.LocationForecast{}, err
+	}
+	forecast := models.LocationForecast{
+		Provider: c.Name(),
+		Timezone: raw.City.Timezone,
+		Sunrise:  time.Unix(raw.City.Sunrise, 0),
+		Sunset:   time.Unix(raw.City.Sunset, 0),
+		Slots:    make([]models.ForecastSlot, 0, len(raw.List)),
+	}
+	for _, info := range raw.List {
+		slot := models.ForecastSlot{
+			Date:        time.Unix(info.Dt, 0),
+			Temperature: info.Main.Temp,
+			FeelsLike:   info.Main.FeelsLike,
+			Humidity:    info.Main.Humidity,
+			Pressure:    info.Main.Pressure,
+			Pop:         info.Pop,
+			Clouds:      info.Clouds.All,
+			WindSpeed:   info.Wind.Speed,
+			WindDegree:  info.Wind.Deg,
+			WindGust:    info.Wind.Gust,
+			Visibility:  info.Visibility,
+			Pod:         info.Sys.Pod,
+		}
+		if len(info.Weather) > 0 {
+			slot.Description = info.Weather[0].Description
+			slot.Icon = info.Weather[0].Icon
+		}
+		if info.Rain != nil {
+			slot.Rain = info.Rain.ThreeHour
+		}
+		forecast.Slots = append(forecast.Slots, slot)
+	}
+	return forecast, nil
+}
+
+// WeatherAt возвращает ответ OpenWeatherMap без преобразования.
+func (c *OpenWeatherMap) WeatherAt(ctx context.Context, loc models.Location) (models.WeatherForecast, error) {
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%f", loc.Lat))
+	params.Set("lon", fmt.Sprintf("%f", loc.Lng))
+	params.Set("units", "metric")
+	params.Set("lang", c.lang)
+	params.Set("appid", c.token)
+	body, err := c.client.Get(ctx, c.baseURL+"/data/2.5/forecast?"+params.Encode())
+	if err != nil {
+		return models.WeatherForecast{}, err
+	}
+	var weatherResponse models.WeatherForecast
+	if err := json.Unmarshal(body, &weatherResponse); err != nil {
+		return models.WeatherForecast{}, fmt.Errorf("%s: %w: %v", c.Name(), ErrInvalidResponse, err)
+	}
+	return weatherResponse, nil
+}
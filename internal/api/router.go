@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+	"trailblazer/internal/utils"
+)
+
+// Router рассчитывает расстояние и время в пути между точками.
+type Router interface {
+	Route(mode models.TravelMode, from, to models.Location) (models.TravelEstimate, error)
+	Matrix(mode models.TravelMode, points []models.Location) (models.TravelMatrix, error)
+}
+
+// NewRouter создаёт маршрутизатор по настройкам routing. Для способов
+// передвижения без адреса OSRM и при ошибках OSRM используется расчёт по прямой.
+func NewRouter(cfg config.RoutingConfig) Router {
+	router := &FallbackRouter{
+		primary:  make(map[models.TravelMode]Router),
+		fallback: NewHaversineRouter(),
+	}
+	client := &http.Client{Timeout: cfg.Timeout}
+	if cfg.CarURL != "" {
+		router.primary[models.TravelModeCar] = NewOSRMRouter(cfg.CarURL, cfg.CarProfile, client)
+	}
+	if cfg.FootURL != "" {
+		router.primary[models.TravelModeFoot] = NewOSRMRouter(cfg.FootURL, cfg.FootProfile, client)
+	}
+	return router
+}
+
+// OSRMRouter - клиент HTTP API, совместимого с OSRM (сервисы route и table).
+type OSRMRouter struct {
+	baseURL string
+	profile string
+	Client  *http.Client
+}
+
+func NewOSRMRouter(baseURL, profile string, client *http.Client) *OSRMRouter {
+	return &OSRMRouter{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		profile: profile,
+		Client:  client,
+	}
+}
+
+type osrmRouteResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Routes  []struct {
+		Distance float64 `json:"distance"`
+		Duration float64 `json:"duration"`
+	} `json:"routes"`
+}
+
+type osrmTableResponse struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Distances [][]*float64 `json:"distances"`
+	Durations [][]*float64 `json:"durations"`
+}
+
+func (r *OSRMRouter) Route(mode models.TravelMode, from, to models.Location) (models.TravelEstimate, error) {
+	var resp osrmRouteResponse
+	if err := r.get("route", []models.Location{from, to}, "overview=false", &resp); err != nil {
+		return models.TravelEstimate{}, err
+	}
+	if resp.Code != "Ok" || len(resp.Routes) == 0 {
+		return models.TravelEstimate{}, fmt.Errorf("osrm route: %s %s", resp.Code, resp.Message)
+	}
+	return models.TravelEstimate{
+		Mode:     mode,
+		Distance: resp.Routes[0].Distance,
+		Duration: resp.Routes[0].Duration,
+		Source:   "osrm",
+	}, nil
+}
+
+func (r *OSRMRouter) Matrix(mode models.TravelMode, points []models.Location) (models.TravelMatrix, error) {
+	var resp osrmTableResponse
+	if err := r.get("table", points, "annotations=distance,duration", &resp); err != nil {
+		return models.TravelMatrix{}, err
+	}
+	if resp.Code != "Ok" {
+		return models.TravelMatrix{}, fmt.Errorf("osrm table: %s %s", resp.Code, resp.Message)
+	}
+	return models.TravelMatrix{
+		Mode:      mode,
+		Distances: resp.Distances,
+		Durations: resp.Durations,
+		Source:    "osrm",
+	}, nil
+}
+
+func (r *OSRMRouter) get(service string, points []models.Location, query string, v any) error {
+	coords := make([]string, len(points))
+	for i, p := range points {
+		coords[i] = fmt.Sprintf("%f,%f", p.Lng, p.Lat)
+	}
+	u := fmt.Sprintf("%s/%s/v1/%s/%s?%s", r.baseURL, service, r.profile, strings.Join(coords, ";"), query)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// OSRM возвращает описание ошибки в теле и при статусе 400.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// Поправочные коэффициенты извилистости дорог и средние скорости (м/с)
+// для оценки по прямой.
+var haversineProfiles = map[models.TravelMode]struct {
+	detour float64
+	speed  float64
+}{
+	models.TravelModeCar:  {detour: 1.4, speed: 50 / 3.6},
+	models.TravelModeFoot: {detour: 1.25, speed: 4.5 / 3.6},
+}
+
+// HaversineRouter оценивает путь по расстоянию по прямой без обращения к внешним сервисам.
+type HaversineRouter struct{}
+
+func NewHaversineRouter() *HaversineRouter {
+	return &HaversineRouter{}
+}
+
+func (r *HaversineRouter) Route(mode models.TravelMode, from, to models.Location) (models.TravelEstimate, error) {
+	p, ok := haversineProfiles[mode]
+	if !ok {
+		return models.TravelEstimate{}, fmt.Errorf("unknown travel mode: %s", mode)
+	}
+	distance := utils.Haversine(from, to) * p.detour
+	return models.TravelEstimate{
+		Mode:     mode,
+		Distance: distance,
+		Duration: distance / p.speed,
+		Source:   "haversine",
+	}, nil
+}
+
+func (r *HaversineRouter) Matrix(mode models.TravelMode, points []models.Location) (models.TravelMatrix, error) {
+	matrix := models.TravelMatrix{
+		Mode:      mode,
+		Distances: make([][]*float64, len(points)),
+		Durations: make([][]*float64, len(points)),
+		Source:    "haversine",
+	}
+	for i := range points {
+		matrix.Distances[i] = make([]*float64, len(points))
+		matrix.Durations[i] = make([]*float64, len(points))
+		for j := range points {
+			estimate, err := r.Route(mode, points[i], points[j])
+			if err != nil {
+				return models.TravelMatrix{}, err
+			}
+			matrix.Distances[i][j] = &estimate.Distance
+			matrix.Durations[i][j] = &estimate.Duration
+		}
+	}
+	return matrix, nil
+}
+
+// FallbackRouter обращается к основному маршрутизатору для способа передвижения,
+// а при его отсутствии или ошибке - к запасному.
+type FallbackRouter struct {
+	primary  map[models.TravelMode]Router
+	fallback Router
+}
+
+func (r *FallbackRouter) Route(mode models.TravelMode, from, to models.Location) (models.TravelEstimate, error) {
+	if primary, ok := r.primary[mode]; ok {
+		estimate, err := primary.Route(mode, from, to)
+		if err == nil {
+			return estimate, nil
+		}
+		slog.Warn(fmt.Sprintf("routing provider failed, using fallback: %v", err))
+	}
+	return r.fallback.Route(mode, from, to)
+}
+
+func (r *FallbackRouter) Matrix(mode models.TravelMode, points []models.Location) (models.TravelMatrix, error) {
+	if primary, ok := r.primary[mode]; ok {
+		matrix, err := primary.Matrix(mode, points)
+		if err == nil {
+			return matrix, nil
+		}
+		slog.Warn(fmt.Sprintf("routing provider failed, using fallback: %v", err))
+	}
+	return r.fallback.Matrix(mode, points)
+}
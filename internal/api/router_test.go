@@ -0,0 +1,158 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+)
+
+var (
+	simferopol = models.Location{Lat: 44.952, Lng: 34.102}
+	yalta      = models.Location{Lat: 44.495, Lng: 34.166}
+)
+
+// newOSRMServer имитирует OSRM: отвечает на /route и /table заранее заданным JSON.
+func newOSRMServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func osrmHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/route/v1/driving/"):
+			if r.URL.Query().Get("overview") != "false" {
+				t.Errorf("route query = %q, want overview=false", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"code":"Ok","routes":[{"distance":84321.5,"duration":5400.2}]}`))
+		case strings.HasPrefix(r.URL.Path, "/table/v1/driving/"):
+			_, _ = w.Write([]byte(`{"code":"Ok","distances":[[0,84321.5],[84100,0]],"durations":[[0,5400.2],[5350,0]]}`))
+		case strings.HasPrefix(r.URL.Path, "/route/v1/foot/"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"NoRoute","message":"Impossible route between points"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestOSRMRouterRoute(t *testing.T) {
+	server := newOSRMServer(t, osrmHandler(t))
+	tests := []struct {
+		name    string
+		profile string
+		want    models.TravelEstimate
+		wantErr bool
+	}{
+		{
+			name:    "ok",
+			profile: "driving",
+			want:    models.TravelEstimate{Mode: models.TravelModeCar, Distance: 84321.5, Duration: 5400.2, Source: "osrm"},
+		},
+		{name: "no route", profile: "foot", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewOSRMRouter(server.URL+"/", tt.profile, server.Client())
+			got, err := router.Route(models.TravelModeCar, simferopol, yalta)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Route() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Route() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Route() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSRMRouterMatrix(t *testing.T) {
+	server := newOSRMServer(t, osrmHandler(t))
+	router := NewOSRMRouter(server.URL, "driving", server.Client())
+	got, err := router.Matrix(models.TravelModeCar, []models.Location{simferopol, yalta})
+	if err != nil {
+		t.Fatalf("Matrix() error = %v", err)
+	}
+	if got.Source != "osrm" || len(got.Distances) != 2 || len(got.Durations) != 2 {
+		t.Fatalf("Matrix() = %+v", got)
+	}
+	if d := got.Distances[1][0]; d == nil || *d != 84100 {
+		t.Errorf("distance[1][0] = %v, want 84100", d)
+	}
+	if d := got.Durations[0][1]; d == nil || *d != 5400.2 {
+		t.Errorf("duration[0][1] = %v, want 5400.2", d)
+	}
+}
+
+func TestFallbackRouter(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		timeout    time.Duration
+		wantSource string
+	}{
+		{
+			name:       "osrm ok",
+			handler:    osrmHandler(t),
+			timeout:    time.Second,
+			wantSource: "osrm",
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			timeout:    time.Second,
+			wantSource: "haversine",
+		},
+		{
+			name: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			},
+			timeout:    50 * time.Millisecond,
+			wantSource: "haversine",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newOSRMServer(t, tt.handler)
+			router := NewRouter(config.RoutingConfig{CarURL: server.URL, CarProfile: "driving", Timeout: tt.timeout})
+
+			estimate, err := router.Route(models.TravelModeCar, simferopol, yalta)
+			if err != nil {
+				t.Fatalf("Route() error = %v", err)
+			}
+			if estimate.Source != tt.wantSource {
+				t.Errorf("Route() source = %s, want %s", estimate.Source, tt.wantSource)
+			}
+			if estimate.Distance <= 0 || estimate.Duration <= 0 {
+				t.Errorf("Route() = %+v, want positive distance and duration", estimate)
+			}
+
+			matrix, err := router.Matrix(models.TravelModeCar, []models.Location{simferopol, yalta})
+			if err != nil {
+				t.Fatalf("Matrix() error = %v", err)
+			}
+			if matrix.Source != tt.wantSource {
+				t.Errorf("Matrix() source = %s, want %s", matrix.Source, tt.wantSource)
+			}
+		})
+	}
+}
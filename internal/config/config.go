@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -14,6 +15,11 @@ type Config struct {
 	HostConfig
 	WeatherConfig
 	ParserConfig
+	SearchConfig
+	TileConfig
+	RoutingConfig
+	CheckInConfig
+	BundleConfig
 }
 type HostConfig struct {
 	Port string
@@ -29,15 +35,76 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 type WeatherConfig struct {
-	WeatherUrl string
-	ApiKey     string
-	Language   string
+	// Provider - поставщик прогноза: openweathermap или openmeteo.
+	Provider     string
+	WeatherUrl   string
+	OpenMeteoURL string
+	ApiKey       string
+	Language     string
+	// Timeout - тайм-аут одного HTTP-запроса к поставщику.
+	Timeout time.Duration
+	// MaxRetries - число повторов при ответах 429 и 5xx и сетевых ошибках.
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// RateLimit - допустимое число запросов в минуту по тарифу поставщика.
+	RateLimit float64
+	// BreakerThreshold - число ошибок подряд, после которого запросы
+	// не отправляются в течение BreakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// GridSize - размер ячейки сетки в градусах. Для всех достопримечательностей
+	// ячейки прогноз запрашивается один раз.
+	GridSize float64
+	// Workers - число одновременных запросов к поставщику при обновлении.
+	Workers int
+	// RefreshInterval - период обновления прогнозов сервером. 0 отключает расписание.
+	RefreshInterval time.Duration
+	// Timezone - часовой пояс, в котором прогноз сводится по дням.
+	Timezone string
+	// RetentionInterval - период сведения прошедших прогнозов в климатическую
+	// статистику. 0 отключает расписание.
+	RetentionInterval time.Duration
 }
 type ParserConfig struct {
 	IsProduction bool
 	BaseURL      string
 }
 
+type SearchConfig struct {
+	Backend string
+	Source  string
+	// DistanceScale - расстояние в километрах, на котором релевантность
+	// результата поиска уменьшается вдвое, если передана точка пользователя.
+	DistanceScale float64
+}
+
+type TileConfig struct {
+	CacheDir string
+	CacheTTL time.Duration
+}
+
+type RoutingConfig struct {
+	CarURL      string
+	CarProfile  string
+	FootURL     string
+	FootProfile string
+	Timeout     time.Duration
+}
+
+type CheckInConfig struct {
+	Radius float64
+}
+
+type BundleConfig struct {
+	Dir          string
+	ImagesDir    string
+	ImageMaxSize int
+	ImageQuality int
+	// Regions - границы регионов: [долгота ЮЗ, широта ЮЗ, долгота СВ, широта СВ].
+	Regions map[string][]float64
+}
+
 func New(path string) (*Config, error) {
 	viper.SetConfigFile(path)
 	//res, _ := os.ReadDir(".")
@@ -65,13 +132,58 @@ func New(path string) (*Config, error) {
 		Port: viper.GetString("server.port"),
 	}
 	cfg.WeatherConfig = WeatherConfig{
-		WeatherUrl: viper.GetString("weather.url"),
-		ApiKey:     os.Getenv("WEATHER_API"),
-		Language:   viper.GetString("weather.lang"),
+		Provider:     viper.GetString("weather.provider"),
+		WeatherUrl:   viper.GetString("weather.url"),
+		OpenMeteoURL: viper.GetString("weather.open_meteo_url"),
+		ApiKey:       os.Getenv("WEATHER_API"),
+		Language:     viper.GetString("weather.lang"),
+
+		Timeout:          viper.GetDuration("weather.timeout"),
+		MaxRetries:       viper.GetInt("weather.max_retries"),
+		RetryBaseDelay:   viper.GetDuration("weather.retry_base_delay"),
+		RetryMaxDelay:    viper.GetDuration("weather.retry_max_delay"),
+		RateLimit:        viper.GetFloat64("weather.rate_limit"),
+		BreakerThreshold: viper.GetInt("weather.breaker_threshold"),
+		BreakerCooldown:  viper.GetDuration("weather.breaker_cooldown"),
+		GridSize:         viper.GetFloat64("weather.grid_size"),
+		Workers:          viper.GetInt("weather.workers"),
+		RefreshInterval:  viper.GetDuration("weather.refresh_interval"),
+		Timezone:         viper.GetString("weather.timezone"),
+
+		RetentionInterval: viper.GetDuration("weather.retention_interval"),
 	}
 	cfg.ParserConfig = ParserConfig{
 		IsProduction: viper.GetBool("parser.is_production"),
 		BaseURL:      viper.GetString("parser.base_url"),
 	}
+	cfg.SearchConfig = SearchConfig{
+		Backend: viper.GetString("search.backend"),
+		Source:  viper.GetString("search.source"),
+
+		DistanceScale: viper.GetFloat64("search.distance_scale"),
+	}
+	cfg.TileConfig = TileConfig{
+		CacheDir: viper.GetString("tiles.cache_dir"),
+		CacheTTL: viper.GetDuration("tiles.cache_ttl"),
+	}
+	cfg.RoutingConfig = RoutingConfig{
+		CarURL:      viper.GetString("routing.car_url"),
+		CarProfile:  viper.GetString("routing.car_profile"),
+		FootURL:     viper.GetString("routing.foot_url"),
+		FootProfile: viper.GetString("routing.foot_profile"),
+		Timeout:     viper.GetDuration("routing.timeout"),
+	}
+	cfg.CheckInConfig = CheckInConfig{
+		Radius: viper.GetFloat64("checkin.radius"),
+	}
+	cfg.BundleConfig = BundleConfig{
+		Dir:          viper.GetString("bundle.dir"),
+		ImagesDir:    viper.GetString("bundle.images_dir"),
+		ImageMaxSize: viper.GetInt("bundle.image_max_size"),
+		ImageQuality: viper.GetInt("bundle.image_quality"),
+	}
+	if err := viper.UnmarshalKey("bundle.regions", &cfg.BundleConfig.Regions); err != nil {
+		slog.Warn(fmt.Sprintf("failed to parse bundle regions: %v", err))
+	}
 	return &cfg, nil
 }
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"strings"
+
+	"trailblazer/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) getSynonyms(c *fiber.Ctx) error {
+	synonyms, err := h.service.SynonymService.GetSynonyms()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(synonyms)
+}
+
+func (h *Handler) addSynonym(c *fiber.Ctx) error {
+	var req models.Synonym
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if strings.TrimSpace(req.Term) == "" || len(req.Synonyms) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "term and synonyms are required"})
+	}
+	id, err := h.service.SynonymService.AddSynonym(req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	req.ID = id
+	return c.Status(fiber.StatusCreated).JSON(req)
+}
+
+func (h *Handler) updateSynonym(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+	}
+	var req models.Synonym
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if strings.TrimSpace(req.Term) == "" || len(req.Synonyms) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "term and synonyms are required"})
+	}
+	req.ID = id
+	if err := h.service.SynonymService.UpdateSynonym(req); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(req)
+}
+
+func (h *Handler) deleteSynonym(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+	}
+	if err := h.service.SynonymService.DeleteSynonym(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
 	"strings"
 
 	"trailblazer/internal/models"
+	"trailblazer/internal/service"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,21 +16,33 @@ import (
 func (h *Handler) facilities(c *fiber.Ctx) error {
 	var req models.BBOX
 	if err := c.BodyParser(&req); err != nil {
-		c.Status(fiber.StatusBadRequest)
-		_, _ = c.WriteString(err.Error())
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
-	facilities, err := h.service.GetFacilities(req)
-	for i := range facilities {
-		facilities[i].WeatherResponse, err = h.service.WeatherService.GetWeatherByLandmarkID(facilities[i].ID)
+	if service.ShouldCluster(req.Zoom) {
+		clusters, err := h.service.GetFacilityClusters(req)
+		if errors.Is(err, service.ErrInvalidArea) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
 		if err != nil {
-			slog.Error(fmt.Sprintf("error with finding weather %v", err))
-			continue
+			return c.Status(404).JSON(fiber.Map{"error": err.Error()})
 		}
+		return sendClusters(c, clusters)
+	}
+	withWeather, daily, err := weatherOptions(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	facilities, err := h.service.GetFacilities(req)
+	if errors.Is(err, service.ErrInvalidArea) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 	if err != nil {
 		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(facilities)
+	if withWeather {
+		h.attachWeather(facilities, daily)
+	}
+	return sendLandmarks(c, facilities)
 }
 func (h *Handler) getLandmarks(ctx *fiber.Ctx) error {
 	var page int
@@ -39,23 +53,23 @@ func (h *Handler) getLandmarks(ctx *fiber.Ctx) error {
 			categories = append(categories, fmt.Sprintf("'%s'", strings.ToLower(string(val))))
 		}
 	})
-	page, err := strconv.Atoi(p)
+	withWeather, daily, err := weatherOptions(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	page, err = strconv.Atoi(p)
 	if err != nil {
 		page = 1
 		err = nil
 	}
 	landmarks, err := h.service.LandmarkService.GetLandmarks(page, categories)
-	for i := range landmarks {
-		landmarks[i].WeatherResponse, err = h.service.WeatherService.GetWeatherByLandmarkID(landmarks[i].ID)
-		if err != nil {
-			slog.Error(fmt.Sprintf("error with finding weather %v", err))
-			continue
-		}
-	}
 	if err != nil {
 		return ctx.JSON(fiber.Map{"error": err.Error()})
 	}
-	return ctx.JSON(landmarks)
+	if withWeather {
+		h.attachWeather(landmarks, daily)
+	}
+	return sendLandmarks(ctx, landmarks)
 
 }
 
@@ -65,23 +79,27 @@ func (h *Handler) getLandmarksByIDs(ctx *fiber.Ctx) error {
 
 		return ctx.JSON(fiber.Map{"error": err.Error()})
 	}
-	points, err := h.service.GetLandmarksByIDs(req)
-	for i := range points {
-		points[i].WeatherResponse, err = h.service.WeatherService.GetWeatherByLandmarkID(points[i].ID)
-		if err != nil {
-			slog.Error(fmt.Sprintf("error with finding weather %v", err))
-			continue
-		}
+	withWeather, daily, err := weatherOptions(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
+	points, err := h.service.GetLandmarksByIDs(req)
 	if err != nil {
-		return ctx.JSON(fiber.Map{"error": err.Error})
+		return ctx.JSON(fiber.Map{"error": err.Error()})
+	}
+	if withWeather {
+		h.attachWeather(points, daily)
 	}
 	return ctx.JSON(points)
 }
 
 func (h *Handler) search(ctx *fiber.Ctx) error {
 	query := ctx.Query("q", "1")
-	landmarks, err := h.service.LandmarkService.Search(query)
+	origin, err := QueryLocation(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	landmarks, err := h.service.LandmarkService.Search(query, origin)
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err})
 	}
@@ -90,6 +108,10 @@ func (h *Handler) search(ctx *fiber.Ctx) error {
 
 func (h *Handler) getLandmarksByName(ctx *fiber.Ctx) error {
 	name := ctx.Params("name")
+	daily, err := weatherDaily(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 
 	landmark, err := h.service.LandmarkService.GetLandmarksByName(name)
 	if err != nil {
@@ -99,9 +121,51 @@ func (h *Handler) getLandmarksByName(ctx *fiber.Ctx) error {
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err})
 	}
+	if daily {
+		landmark.DailyWeather = h.service.WeatherService.DailySummary(*landmark.WeatherResponse)
+		landmark.WeatherResponse = nil
+	}
+	landmark.Nearby, err = h.service.LandmarkService.GetNearby(models.NearbyQuery{
+		Location:  landmark.Location,
+		ExcludeID: landmark.ID,
+		Limit:     nearbyBlockSize,
+	})
+	if err != nil {
+		slog.Error(fmt.Sprintf("error with finding nearby landmarks %v", err))
+	}
 	return ctx.JSON(landmark)
 }
 
+// nearbyBlockSize - количество мест в блоке «рядом» на странице достопримечательности.
+const nearbyBlockSize = 6
+
+func (h *Handler) getNearby(ctx *fiber.Ctx) error {
+	origin, err := QueryLocation(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if origin == nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "lat and lng are required"})
+	}
+	q := models.NearbyQuery{Location: *origin, Limit: ctx.QueryInt("limit")}
+	if r := ctx.Query("radius"); r != "" {
+		q.Radius, err = strconv.ParseFloat(r, 64)
+		if err != nil || q.Radius <= 0 {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid radius"})
+		}
+	}
+	ctx.Request().URI().QueryArgs().VisitAll(func(key, val []byte) {
+		if string(key) == "category" {
+			q.Categories = append(q.Categories, string(val))
+		}
+	})
+	landmarks, err := h.service.LandmarkService.GetNearby(q)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return sendLandmarks(ctx, landmarks)
+}
+
 func (h *Handler) getLandmarksByCategories(ctx *fiber.Ctx) error {
 	var categories []string
 	ctx.Request().URI().QueryArgs().VisitAll(func(key, val []byte) {
@@ -121,3 +185,127 @@ func (h *Handler) getLandmarksByCategories(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(landmarks)
 }
+
+// QueryLocation читает необязательные параметры lat и lng из строки запроса.
+// Если ни один из них не передан, возвращается nil.
+func QueryLocation(ctx *fiber.Ctx) (*models.Location, error) {
+	latStr, lngStr := ctx.Query("lat"), ctx.Query("lng")
+	if latStr == "" && lngStr == "" {
+		return nil, nil
+	}
+	if latStr == "" || lngStr == "" {
+		return nil, fmt.Errorf("both lat and lng must be provided")
+	}
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("invalid lat: %s", latStr)
+	}
+	lng, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return nil, fmt.Errorf("invalid lng: %s", lngStr)
+	}
+	return &models.Location{Lat: lat, Lng: lng}, nil
+}
+
+const geoJSONContentType = "application/geo+json"
+
+// wantsGeoJSON сообщает, запросил ли клиент ответ в формате GeoJSON
+// через ?format=geojson или заголовок Accept.
+func wantsGeoJSON(ctx *fiber.Ctx) bool {
+	if format := ctx.Query("format"); format != "" {
+		return strings.EqualFold(format, "geojson")
+	}
+	return strings.Contains(ctx.Get(fiber.HeaderAccept), geoJSONContentType)
+}
+
+// sendLandmarks отправляет список достопримечательностей в виде JSON
+// или FeatureCollection, в зависимости от запроса клиента.
+func sendLandmarks(ctx *fiber.Ctx, landmarks []models.Landmark) error {
+	ctx.Vary(fiber.HeaderAccept)
+	if !wantsGeoJSON(ctx) {
+		return ctx.JSON(landmarks)
+	}
+	return sendGeoJSON(ctx, models.NewFeatureCollection(landmarks))
+}
+
+// sendGeoJSON отправляет объект GeoJSON с типом содержимого application/geo+json.
+func sendGeoJSON(ctx *fiber.Ctx, v any) error {
+	if err := ctx.JSON(v); err != nil {
+		return err
+	}
+	ctx.Set(fiber.HeaderContentType, geoJSONContentType)
+	return nil
+}
+
+// sendClusters отправляет кластеры в виде JSON или FeatureCollection.
+func sendClusters(ctx *fiber.Ctx, clusters models.FacilityClusters) error {
+	ctx.Vary(fiber.HeaderAccept)
+	if !wantsGeoJSON(ctx) {
+		return ctx.JSON(clusters)
+	}
+	return sendGeoJSON(ctx, models.NewClusterFeatureCollection(clusters))
+}
+
+// weatherDaily сообщает, запрошен ли прогноз по дням (granularity=daily).
+// По умолчанию и при granularity=3h возвращаются 3-часовые интервалы.
+func weatherDaily(ctx *fiber.Ctx) (bool, error) {
+	switch granularity := ctx.Query("granularity"); granularity {
+	case "", "3h":
+		return false, nil
+	case "daily":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown granularity: %s", granularity)
+	}
+}
+
+// weatherOptions разбирает параметры include и granularity списочных запросов.
+// Прогноз загружается только по запросу: include=weather или granularity=daily.
+func weatherOptions(ctx *fiber.Ctx) (bool, bool, error) {
+	daily, err := weatherDaily(ctx)
+	if err != nil {
+		return false, false, err
+	}
+	include := daily
+	for _, part := range strings.Split(ctx.Query("include"), ",") {
+		if strings.TrimSpace(part) == "weather" {
+			include = true
+		}
+	}
+	return include, daily, nil
+}
+
+// attachWeather загружает прогноз для всех landmarks одним запросом. Ошибка
+// загрузки прогноза не мешает отдать список и только записывается в лог.
+func (h *Handler) attachWeather(landmarks []models.Landmark, daily bool) {
+	ids := make([]int, len(landmarks))
+	for i, l := range landmarks {
+		ids[i] = l.ID
+	}
+	weather, err := h.service.WeatherService.GetWeatherByLandmarkIDs(ids)
+	if err != nil {
+		slog.Error(fmt.Sprintf("error with finding weather %v", err))
+		return
+	}
+	for i := range landmarks {
+		forecast, ok := weather[landmarks[i].ID]
+		if !ok {
+			forecast = make([]models.WeatherResponse, 0)
+		}
+		landmarks[i].WeatherResponse = &forecast
+	}
+	if daily {
+		h.summarizeWeather(landmarks)
+	}
+}
+
+// summarizeWeather заменяет интервалы прогноза сводкой по дням.
+func (h *Handler) summarizeWeather(landmarks []models.Landmark) {
+	for i := range landmarks {
+		if landmarks[i].WeatherResponse == nil {
+			continue
+		}
+		landmarks[i].DailyWeather = h.service.WeatherService.DailySummary(*landmarks[i].WeatherResponse)
+		landmarks[i].WeatherResponse = nil
+	}
+}
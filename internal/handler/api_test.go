@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"trailblazer/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSendLandmarks(t *testing.T) {
+	landmarks := []models.Landmark{{ID: 1, Name: "Кремль", Location: models.Location{Lat: 55.75, Lng: 37.61}}}
+	app := fiber.New()
+	app.Get("/landmarks", func(ctx *fiber.Ctx) error {
+		return sendLandmarks(ctx, landmarks)
+	})
+	tests := []struct {
+		name        string
+		target      string
+		accept      string
+		wantGeoJSON bool
+	}{
+		{name: "plain json", target: "/landmarks"},
+		{name: "accept geojson", target: "/landmarks", accept: "application/geo+json", wantGeoJSON: true},
+		{name: "accept list", target: "/landmarks", accept: "application/json, application/geo+json;q=0.9", wantGeoJSON: true},
+		{name: "format geojson", target: "/landmarks?format=geojson", wantGeoJSON: true},
+		{name: "format case insensitive", target: "/landmarks?format=GeoJSON", wantGeoJSON: true},
+		{name: "format overrides accept", target: "/landmarks?format=json", accept: "application/geo+json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("status = %d, want 200", resp.StatusCode)
+			}
+			if vary := resp.Header.Get(fiber.HeaderVary); vary != fiber.HeaderAccept {
+				t.Errorf("Vary = %q, want Accept", vary)
+			}
+			contentType := resp.Header.Get(fiber.HeaderContentType)
+			if !tt.wantGeoJSON {
+				if contentType != fiber.MIMEApplicationJSON {
+					t.Errorf("Content-Type = %q, want %q", contentType, fiber.MIMEApplicationJSON)
+				}
+				var got []models.Landmark
+				if err := json.NewDecoder(resp.Body).Decode(&got); err != nil || len(got) != 1 || got[0].ID != 1 {
+					t.Errorf("body = %+v, %v, want landmark list", got, err)
+				}
+				return
+			}
+			if contentType != geoJSONContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, geoJSONContentType)
+			}
+			var got struct {
+				Type     string
+				Features []struct {
+					ID       int
+					Geometry struct {
+						Type        string
+						Coordinates []float64
+					}
+				}
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("decode FeatureCollection: %v", err)
+			}
+			if got.Type != "FeatureCollection" || len(got.Features) != 1 {
+				t.Fatalf("body = %+v, want FeatureCollection with one feature", got)
+			}
+			geometry := got.Features[0].Geometry
+			if geometry.Type != "Point" || len(geometry.Coordinates) != 2 ||
+				geometry.Coordinates[0] != 37.61 || geometry.Coordinates[1] != 55.75 {
+				t.Errorf("geometry = %+v, want Point [37.61 55.75]", geometry)
+			}
+		})
+	}
+}
+
+func TestQueryLocation(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    *models.Location
+		wantErr string
+	}{
+		{query: "", want: nil},
+		{query: "lat=55.75&lng=37.61", want: &models.Location{Lat: 55.75, Lng: 37.61}},
+		{query: "lat=55.75", wantErr: "both lat and lng must be provided"},
+		{query: "lng=37.61", wantErr: "both lat and lng must be provided"},
+		{query: "lat=91&lng=37.61", wantErr: "invalid lat: 91"},
+		{query: "lat=55.75&lng=east", wantErr: "invalid lng: east"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(ctx *fiber.Ctx) error {
+				got, err := QueryLocation(ctx)
+				if tt.wantErr != "" {
+					if err == nil || err.Error() != tt.wantErr {
+						t.Errorf("QueryLocation() error = %v, want %q", err, tt.wantErr)
+					}
+					return nil
+				}
+				if err != nil {
+					t.Errorf("QueryLocation() error = %v", err)
+				}
+				if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+					t.Errorf("QueryLocation() = %v, want %v", got, tt.want)
+				}
+				return nil
+			})
+			if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/?"+tt.query, nil)); err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) getBundleRegions(c *fiber.Ctx) error {
+	return c.JSON(h.service.BundleService.Regions())
+}
+
+func (h *Handler) getBundle(c *fiber.Ctx) error {
+	region := c.Params("region")
+	manifest, path, err := h.service.BundleService.GetBundle(region)
+	if errors.Is(err, service.ErrUnknownRegion) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	etag := `"` + manifest.Version + `"`
+	c.Set(fiber.HeaderETag, etag)
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Attachment(fmt.Sprintf("%s-%s.zip", region, manifest.Version))
+	return c.SendFile(path)
+}
+
+func (h *Handler) getBundleManifest(c *fiber.Ctx) error {
+	manifest, _, err := h.service.BundleService.GetBundle(c.Params("region"))
+	if errors.Is(err, service.ErrUnknownRegion) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	c.Set(fiber.HeaderETag, `"`+manifest.Version+`"`)
+	return c.JSON(manifest)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) checkIn(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).SendString("invalid or expired token")
+	}
+	var req models.CheckInRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	checkIn, err := h.service.CheckInService.CheckIn(userID, req)
+	switch {
+	case errors.Is(err, service.ErrTooFar):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidCheckIn):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(checkIn)
+}
+
+func (h *Handler) getVisits(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).SendString("invalid or expired token")
+	}
+	visits, err := h.service.CheckInService.GetVisits(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(visits)
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) getClimate(c *fiber.Ctx) error {
+	landmark, err := h.service.LandmarkService.GetLandmarksByName(c.Params("name"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	climate, err := h.service.WeatherService.GetClimate(landmark.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if m := c.Query("month"); m != "" {
+		month, err := strconv.Atoi(m)
+		if err != nil || month < 1 || month > 12 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "month must be between 1 and 12"})
+		}
+		filtered := make([]models.ClimateMonth, 0, 1)
+		for _, cm := range climate.Months {
+			if cm.Month == month {
+				filtered = append(filtered, cm)
+			}
+		}
+		climate.Months = filtered
+	}
+	return c.JSON(climate)
+}
+
+func (h *Handler) getBestTime(c *fiber.Ctx) error {
+	landmark, err := h.service.LandmarkService.GetLandmarksByName(c.Params("name"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	best, err := h.service.WeatherService.BestTime(landmark, c.QueryInt("limit"))
+	if errors.Is(err, service.ErrIndoorLandmark) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(best)
+}
@@ -4,7 +4,6 @@ import (
 	"log/slog"
 	"os"
 
-	"trailblazer/internal/api"
 	"trailblazer/internal/service"
 	"trailblazer/internal/utils"
 
@@ -15,13 +14,12 @@ import (
 
 type Handler struct {
 	service    *service.Service
-	api        api.WeatherAPI
 	TokenMaker utils.Maker
 	hashUtil   utils.Hasher
 }
 
-func NewHandler(service *service.Service, api api.WeatherAPI, hashutil utils.Hasher, maker utils.Maker) *Handler {
-	return &Handler{service: service, api: api, TokenMaker: maker, hashUtil: hashutil}
+func NewHandler(service *service.Service, hashutil utils.Hasher, maker utils.Maker) *Handler {
+	return &Handler{service: service, TokenMaker: maker, hashUtil: hashutil}
 }
 
 func (h *Handler) InitRoutes(app *fiber.App) {
@@ -46,10 +44,17 @@ func (h *Handler) InitRoutes(app *fiber.App) {
 	user.Post("/signUp", h.SignUp)
 	user.Post("/changeProfile", h.ChangeProfile)
 	user.Get("/profile", h.GetUserProfile)
+	user.Post("/checkin", h.JWTMiddleware, h.checkIn)
+	user.Get("/visits", h.JWTMiddleware, h.getVisits)
 	review := user.Group("/review")
 	review.Use("/add/:name", h.JWTMiddleware)
 	review.Post("/add/:name", h.AddReview)
 	review.Get("/get/:name", h.GetReview)
+	tiles := app.Group("/tiles")
+	tiles.Use(cors.New(cors.Config{
+		AllowOrigins:     "*",
+		AllowCredentials: false,
+	})).Get("/:z/:x/:y.mvt", h.getTile)
 	apiGroup := app.Group("/api")
 	apiGroup.Use(cors.New(cors.Config{
 		AllowOrigins:     "*",
@@ -58,6 +63,31 @@ func (h *Handler) InitRoutes(app *fiber.App) {
 	apiGroup.Get("/landmark", h.getLandmarks)
 	apiGroup.Post("/getLandmarks", h.getLandmarksByIDs)
 	apiGroup.Get("/search", h.search)
+	apiGroup.Get("/landmark/nearby", h.getNearby)
 	apiGroup.Get("/landmark/:name", h.getLandmarksByName)
+	apiGroup.Get("/landmark/:name/trails", h.getTrailsNearLandmark)
+	apiGroup.Get("/landmark/:name/climate", h.getClimate)
+	apiGroup.Get("/landmark/:name/best-time", h.getBestTime)
+	apiGroup.Get("/trail/:slug", h.getTrail)
+	apiGroup.Post("/trails", h.getTrailsInBBOX)
+	apiGroup.Get("/bundle", h.getBundleRegions)
+	apiGroup.Get("/bundle/:region", h.getBundle)
+	apiGroup.Get("/bundle/:region/manifest", h.getBundleManifest)
+	apiGroup.Get("/sync", h.sync)
+	apiGroup.Get("/recommendations/today", h.getTodayRecommendations)
+	apiGroup.Post("/route/optimize", h.optimizeRoute)
+	apiGroup.Get("/route/travel", h.travel)
+	apiGroup.Post("/route/matrix", h.travelMatrix)
+	apiGroup.Post("/route/corridor", h.corridor)
+	admin := apiGroup.Group("/admin", h.JWTMiddleware, h.AdminMiddleware)
+	admin.Get("/synonyms", h.getSynonyms)
+	admin.Post("/synonyms", h.addSynonym)
+	admin.Put("/synonyms/:id", h.updateSynonym)
+	admin.Delete("/synonyms/:id", h.deleteSynonym)
+	admin.Delete("/tiles", h.invalidateTiles)
+	admin.Post("/trails", h.importTrail)
+	admin.Get("/jobs", h.getJobs)
+	admin.Get("/jobs/:name", h.getJob)
+	admin.Post("/jobs/:name", h.runJob)
 
 }
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) getJobs(c *fiber.Ctx) error {
+	return c.JSON(h.service.JobService.Jobs())
+}
+
+func (h *Handler) getJob(c *fiber.Ctx) error {
+	status, err := h.service.JobService.JobStatus(c.Params("name"))
+	if errors.Is(err, service.ErrUnknownJob) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(status)
+}
+
+func (h *Handler) runJob(c *fiber.Ctx) error {
+	name := c.Params("name")
+	err := h.service.JobService.TriggerJob(name)
+	if errors.Is(err, service.ErrUnknownJob) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, service.ErrJobRunning) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	status, err := h.service.JobService.JobStatus(name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusAccepted).JSON(status)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) optimizeRoute(c *fiber.Ctx) error {
+	var req models.RouteOptimizeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	route, err := h.service.RouteService.OptimizeRoute(req)
+	if errors.Is(err, service.ErrInvalidRoute) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(route)
+}
+
+func (h *Handler) travel(c *fiber.Ctx) error {
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "from and to must be landmark ids"})
+	}
+	mode := models.TravelMode(c.Query("mode", string(models.TravelModeCar)))
+	estimate, err := h.service.RouteService.Travel(from, to, mode)
+	if errors.Is(err, service.ErrInvalidRoute) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(estimate)
+}
+
+func (h *Handler) travelMatrix(c *fiber.Ctx) error {
+	var req models.TravelMatrixRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if req.Mode == "" {
+		req.Mode = models.TravelModeCar
+	}
+	matrix, err := h.service.RouteService.TravelMatrix(req)
+	if errors.Is(err, service.ErrInvalidRoute) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(matrix)
+}
+
+func (h *Handler) corridor(c *fiber.Ctx) error {
+	var req models.CorridorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	landmarks, err := h.service.RouteService.Corridor(req)
+	if errors.Is(err, service.ErrInvalidRoute) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(landmarks)
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"errors"
+
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) sync(c *fiber.Ctx) error {
+	changes, err := h.service.SyncService.Changes(c.Query("since"))
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(changes)
+}
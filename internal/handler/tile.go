@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const mvtContentType = "application/vnd.mapbox-vector-tile"
+
+func (h *Handler) getTile(c *fiber.Ctx) error {
+	z, errZ := c.ParamsInt("z")
+	x, errX := c.ParamsInt("x")
+	y, errY := c.ParamsInt("y")
+	if errZ != nil || errX != nil || errY != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": service.ErrInvalidTile.Error()})
+	}
+	tile, err := h.service.TileService.GetTile(z, x, y)
+	if errors.Is(err, service.ErrInvalidTile) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		slog.Error(fmt.Sprintf("error with building tile %d/%d/%d: %v", z, x, y, err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if len(tile) == 0 {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	c.Set(fiber.HeaderContentType, mvtContentType)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	return c.Send(tile)
+}
+
+func (h *Handler) invalidateTiles(c *fiber.Ctx) error {
+	if err := h.service.TileService.InvalidateTiles(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handler) importTrail(c *fiber.Ctx) error {
+	file, err := c.FormFile("gpx")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "gpx file is required"})
+	}
+	f, err := file.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	trail, err := h.service.TrailService.ImportGPX(data, models.TrailImport{
+		Name:        c.FormValue("name"),
+		Description: c.FormValue("description"),
+		Difficulty:  c.FormValue("difficulty"),
+	})
+	if errors.Is(err, service.ErrInvalidTrail) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, service.ErrTrailExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	c.Status(fiber.StatusCreated)
+	return sendGeoJSON(c, models.NewTrailFeature(trail))
+}
+
+func (h *Handler) getTrail(c *fiber.Ctx) error {
+	trail, err := h.service.TrailService.GetTrail(c.Params("slug"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return sendGeoJSON(c, models.NewTrailFeature(trail))
+}
+
+func (h *Handler) getTrailsNearLandmark(c *fiber.Ctx) error {
+	var radius float64
+	if r := c.Query("radius"); r != "" {
+		var err error
+		radius, err = strconv.ParseFloat(r, 64)
+		if err != nil || radius <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid radius"})
+		}
+	}
+	trails, err := h.service.TrailService.GetTrailsNearLandmark(c.Params("name"), radius)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return sendGeoJSON(c, models.NewTrailFeatureCollection(trails))
+}
+
+func (h *Handler) getTrailsInBBOX(c *fiber.Ctx) error {
+	var req models.BBOX
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	trails, err := h.service.TrailService.GetTrailsInBBOX(req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return sendGeoJSON(c, models.NewTrailFeatureCollection(trails))
+}
@@ -172,9 +172,23 @@ func (h *Handler) JWTMiddleware(c *fiber.Ctx) error {
 	}
 	tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 
-	_, err := h.TokenMaker.VerifyToken(tokenStr)
+	payload, err := h.TokenMaker.VerifyToken(tokenStr)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).SendString("invalid or expired token")
 	}
+	c.Locals("user_id", payload.UserID)
+	return c.Next()
+}
+
+// AdminMiddleware подключается после JWTMiddleware и пропускает только администраторов.
+func (h *Handler) AdminMiddleware(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).SendString("invalid or expired token")
+	}
+	isAdmin, err := h.service.UserService.IsAdmin(c.Context(), userID)
+	if err != nil || !isAdmin {
+		return c.Status(fiber.StatusForbidden).SendString("admin access required")
+	}
 	return c.Next()
 }
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// BundleManifest описывает содержимое офлайн-пакета региона. Version зависит
+// только от содержимого файлов, поэтому клиент может сравнивать пакеты
+// и скачивать только изменившиеся файлы.
+type BundleManifest struct {
+	Region      string       `json:"region"`
+	Version     string       `json:"version"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Files       []BundleFile `json:"files"`
+}
+
+type BundleFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
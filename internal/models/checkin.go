@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// CheckInRequest - отметка пользователя о посещении достопримечательности.
+type CheckInRequest struct {
+	LandmarkID int     `json:"landmark_id"`
+	Lat        float64 `json:"lat"`
+	Lng        float64 `json:"lng"`
+}
+
+// CheckIn - принятая отметка о посещении. Distance - расстояние до
+// достопримечательности в момент отметки (м).
+type CheckIn struct {
+	ID         int       `json:"id"`
+	UserID     int64     `json:"user_id"`
+	LandmarkID int       `json:"landmark_id"`
+	Location   Location  `json:"location"`
+	Distance   float64   `json:"distance"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Visit - достопримечательность, которую посетил пользователь.
+type Visit struct {
+	Landmark       Landmark  `json:"landmark"`
+	CheckIns       int       `json:"checkins"`
+	FirstVisitedAt time.Time `json:"first_visited_at"`
+	LastVisitedAt  time.Time `json:"last_visited_at"`
+}
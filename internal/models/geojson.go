@@ -0,0 +1,128 @@
+package models
+
+// FeatureCollection - коллекция объектов в формате GeoJSON (RFC 7946).
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string   `json:"type"`
+	ID         int      `json:"id,omitempty"`
+	Geometry   Geometry `json:"geometry"`
+	Properties any      `json:"properties"`
+}
+
+// Geometry - геометрия GeoJSON. Координаты указываются в порядке [долгота, широта].
+type Geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// LandmarkProperties - свойства достопримечательности без координат.
+type LandmarkProperties struct {
+	Name            string             `json:"name"`
+	TranslatedName  string             `json:"translated_name"`
+	Address         string             `json:"address"`
+	Category        string             `json:"category"`
+	Description     string             `json:"description,omitempty"`
+	History         string             `json:"history,omitempty"`
+	ImagePath       string             `json:"image_path"`
+	WeatherResponse *[]WeatherResponse `json:"weathers,omitempty"`
+	DailyWeather    []DailyWeather     `json:"daily_weather,omitempty"`
+	Distance        *float64           `json:"distance,omitempty"`
+}
+
+// NewPointGeometry создаёт геометрию Point для заданной точки.
+func NewPointGeometry(loc Location) Geometry {
+	return Geometry{Type: "Point", Coordinates: [2]float64{loc.Lng, loc.Lat}}
+}
+
+// NewFeatureCollection преобразует список достопримечательностей в FeatureCollection.
+func NewFeatureCollection(landmarks []Landmark) FeatureCollection {
+	features := make([]Feature, 0, len(landmarks))
+	for _, l := range landmarks {
+		features = append(features, Feature{
+			Type:     "Feature",
+			ID:       l.ID,
+			Geometry: NewPointGeometry(l.Location),
+			Properties: LandmarkProperties{
+				Name:            l.Name,
+				TranslatedName:  l.TranslatedName,
+				Address:         l.Address,
+				Category:        l.Category,
+				Description:     l.Description,
+				History:         l.History,
+				ImagePath:       l.ImagePath,
+				WeatherResponse: l.WeatherResponse,
+				DailyWeather:    l.DailyWeather,
+				Distance:        l.Distance,
+			},
+		})
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// ClusterProperties - свойства кластера, совместимые с соглашениями supercluster.
+type ClusterProperties struct {
+	Cluster       bool            `json:"cluster"`
+	PointCount    int             `json:"point_count"`
+	TopCategories []CategoryCount `json:"top_categories"`
+	IDs           []int           `json:"ids"`
+}
+
+// NewClusterFeatureCollection преобразует кластеры и одиночные точки в FeatureCollection.
+func NewClusterFeatureCollection(c FacilityClusters) FeatureCollection {
+	fc := NewFeatureCollection(c.Landmarks)
+	for _, cluster := range c.Clusters {
+		fc.Features = append(fc.Features, Feature{
+			Type:     "Feature",
+			Geometry: NewPointGeometry(cluster.Location),
+			Properties: ClusterProperties{
+				Cluster:       true,
+				PointCount:    cluster.Count,
+				TopCategories: cluster.TopCategories,
+				IDs:           cluster.IDs,
+			},
+		})
+	}
+	return fc
+}
+
+// TrailProperties - свойства тропы в GeoJSON.
+type TrailProperties struct {
+	Name          string  `json:"name"`
+	Slug          string  `json:"slug"`
+	Description   string  `json:"description,omitempty"`
+	Difficulty    string  `json:"difficulty"`
+	Length        float64 `json:"length"`
+	ElevationGain float64 `json:"elevation_gain"`
+	Duration      int     `json:"duration"`
+}
+
+// NewTrailFeature преобразует тропу в Feature с геометрией LineString.
+func NewTrailFeature(t Trail) Feature {
+	return Feature{
+		Type:     "Feature",
+		ID:       t.ID,
+		Geometry: t.Geometry,
+		Properties: TrailProperties{
+			Name:          t.Name,
+			Slug:          t.Slug,
+			Description:   t.Description,
+			Difficulty:    t.Difficulty,
+			Length:        t.Length,
+			ElevationGain: t.ElevationGain,
+			Duration:      t.Duration,
+		},
+	}
+}
+
+// NewTrailFeatureCollection преобразует список троп в FeatureCollection.
+func NewTrailFeatureCollection(trails []Trail) FeatureCollection {
+	features := make([]Feature, 0, len(trails))
+	for _, t := range trails {
+		features = append(features, NewTrailFeature(t))
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
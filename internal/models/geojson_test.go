@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewFeatureCollection(t *testing.T) {
+	distance := 120.5
+	landmarks := []Landmark{
+		{ID: 1, Name: "Кремль", Category: "Музей", ImagePath: "kreml.jpg", Location: Location{Lat: 55.75, Lng: 37.61}, Distance: &distance},
+		{ID: 2, Name: "Мыс", Location: Location{Lat: -33.9, Lng: 18.4}},
+	}
+	data, err := json.Marshal(NewFeatureCollection(landmarks))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			ID       int    `json:"id"`
+			Geometry struct {
+				Type        string     `json:"type"`
+				Coordinates [2]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got.Type != "FeatureCollection" || len(got.Features) != len(landmarks) {
+		t.Fatalf("FeatureCollection = %s", data)
+	}
+	for i, f := range got.Features {
+		l := landmarks[i]
+		if f.Type != "Feature" || f.ID != l.ID || f.Geometry.Type != "Point" {
+			t.Errorf("feature %d = %+v", i, f)
+		}
+		// RFC 7946: сначала долгота, затем широта.
+		if f.Geometry.Coordinates != [2]float64{l.Lng, l.Lat} {
+			t.Errorf("feature %d coordinates = %v, want [%v %v]", i, f.Geometry.Coordinates, l.Lng, l.Lat)
+		}
+		if f.Properties["name"] != l.Name {
+			t.Errorf("feature %d name = %v, want %s", i, f.Properties["name"], l.Name)
+		}
+		for _, key := range []string{"location", "lat", "lng"} {
+			if _, ok := f.Properties[key]; ok {
+				t.Errorf("feature %d properties contain %q", i, key)
+			}
+		}
+	}
+	if got.Features[0].Properties["distance"] != distance {
+		t.Errorf("distance = %v, want %v", got.Features[0].Properties["distance"], distance)
+	}
+}
+
+func TestNewFeatureCollectionEmpty(t *testing.T) {
+	data, err := json.Marshal(NewFeatureCollection(nil))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("empty FeatureCollection = %s", data)
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Способы запуска фоновой задачи.
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+)
+
+// JobRun - итоги одного запуска фоновой задачи.
+type JobRun struct {
+	Trigger    string    `json:"trigger"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// Duration - длительность в секундах.
+	Duration  float64 `json:"duration"`
+	Succeeded int     `json:"succeeded"`
+	Failed    int     `json:"failed"`
+	Error     string  `json:"error,omitempty"`
+	Details   any     `json:"details,omitempty"`
+}
+
+// JobStatus - состояние фоновой задачи и последние запуски, начиная с недавнего.
+type JobStatus struct {
+	Name      string     `json:"name"`
+	Enabled   bool       `json:"enabled"`
+	Interval  string     `json:"interval"`
+	Running   bool       `json:"running"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	LastRun   *JobRun    `json:"last_run,omitempty"`
+	History   []JobRun   `json:"history"`
+}
@@ -17,6 +17,12 @@ type Landmark struct {
 	Location        `json:"location"`
 	ImagePath       string             `json:"image_path"`
 	WeatherResponse *[]WeatherResponse `json:"weathers"`
+	// DailyWeather - прогноз по дням, заполняется вместо WeatherResponse при granularity=daily.
+	DailyWeather []DailyWeather `json:"daily_weather,omitempty"`
+	// Distance - расстояние в метрах от точки запроса, если она была передана.
+	Distance *float64 `json:"distance,omitempty"`
+	// Nearby - ближайшие места, заполняется только на странице достопримечательности.
+	Nearby []Landmark `json:"nearby,omitempty"`
 }
 type Schedule struct {
 	Start       time.Time `json:"start"`
@@ -33,9 +39,52 @@ type Location struct {
 	Lat float64 `json:"lat"`
 	Lng float64 `json:"lng"`
 }
+
+// NearbyQuery описывает поиск достопримечательностей в радиусе от точки.
+type NearbyQuery struct {
+	Location
+	// Radius - радиус поиска в метрах.
+	Radius     float64
+	Categories []string
+	// ExcludeID исключает из выдачи достопримечательность, от которой ведётся поиск.
+	ExcludeID int
+	Limit     int
+}
+
 type BBOX struct {
 	SW Point `json:"sw"`
 	NE Point `json:"ne"`
+	// Zoom - уровень масштаба карты. При малом масштабе точки объединяются в кластеры,
+	// без масштаба возвращаются отдельные точки.
+	Zoom *int `json:"zoom,omitempty"`
+	// Area - произвольная область в формате GeoJSON (Polygon или MultiPolygon).
+	// Если задана, прямоугольник SW/NE не учитывается.
+	Area *Geometry `json:"area,omitempty"`
+	// Center и Radius - круг радиусом Radius метров. Учитывается, если Area не задана.
+	Center *Location `json:"center,omitempty"`
+	Radius float64   `json:"radius,omitempty"`
+}
+
+// Cluster - группа близко расположенных достопримечательностей.
+type Cluster struct {
+	Count int `json:"count"`
+	// Location - центр масс точек кластера.
+	Location      `json:"location"`
+	TopCategories []CategoryCount `json:"top_categories"`
+	IDs           []int           `json:"ids"`
+}
+
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// FacilityClusters - ответ на запрос объектов при малом масштабе: кластеры
+// и одиночные достопримечательности, которым не нашлось соседей.
+type FacilityClusters struct {
+	Zoom      int        `json:"zoom"`
+	Clusters  []Cluster  `json:"clusters"`
+	Landmarks []Landmark `json:"landmarks"`
 }
 
 type Point struct {
@@ -0,0 +1,21 @@
+package models
+
+// Погодные условия, по которым подбираются рекомендации.
+const (
+	ConditionRain    = "rain"
+	ConditionClear   = "clear"
+	ConditionCloudy  = "cloudy"
+	ConditionHot     = "hot"
+	ConditionCold    = "cold"
+	ConditionWindy   = "windy"
+	ConditionUnknown = "unknown"
+)
+
+// Recommendation - достопримечательность, предложенная с учётом погоды.
+type Recommendation struct {
+	Landmark Landmark `json:"landmark"`
+	// Score - итоговая оценка от 0 до 1: соответствие категории погоде и близость.
+	Score     float64 `json:"score"`
+	Condition string  `json:"condition"`
+	Reason    string  `json:"reason"`
+}
@@ -0,0 +1,79 @@
+package models
+
+// RouteOptimizeRequest - запрос на оптимизацию порядка посещения достопримечательностей.
+type RouteOptimizeRequest struct {
+	IDs []int `json:"ids"`
+	// Start - необязательная точка начала маршрута, например положение пользователя.
+	Start *Location `json:"start,omitempty"`
+	// Closed - маршрут возвращается в начальную точку.
+	Closed bool `json:"closed"`
+}
+
+// RouteLeg - участок маршрута между двумя соседними точками. Нулевой
+// идентификатор означает начальную точку маршрута.
+type RouteLeg struct {
+	FromID   int     `json:"from_id"`
+	ToID     int     `json:"to_id"`
+	Distance float64 `json:"distance"`
+}
+
+// OptimizedRoute - достопримечательности в порядке посещения. Расстояния в метрах.
+type OptimizedRoute struct {
+	Start         *Location  `json:"start,omitempty"`
+	Closed        bool       `json:"closed"`
+	Order         []int      `json:"order"`
+	Landmarks     []Landmark `json:"landmarks"`
+	Legs          []RouteLeg `json:"legs"`
+	TotalDistance float64    `json:"total_distance"`
+}
+
+// TravelMode - способ передвижения.
+type TravelMode string
+
+const (
+	TravelModeCar  TravelMode = "car"
+	TravelModeFoot TravelMode = "foot"
+)
+
+// TravelEstimate - расстояние (м) и время в пути (с) между двумя точками.
+// Source показывает, получена ли оценка от сервиса маршрутизации или по прямой.
+type TravelEstimate struct {
+	FromID   int        `json:"from_id,omitempty"`
+	ToID     int        `json:"to_id,omitempty"`
+	Mode     TravelMode `json:"mode"`
+	Distance float64    `json:"distance"`
+	Duration float64    `json:"duration"`
+	Source   string     `json:"source"`
+}
+
+type TravelMatrixRequest struct {
+	IDs  []int      `json:"ids"`
+	Mode TravelMode `json:"mode"`
+}
+
+// TravelMatrix - матрицы расстояний (м) и времени в пути (с) между всеми парами
+// точек в порядке IDs. Значение null означает, что маршрут не найден.
+type TravelMatrix struct {
+	IDs       []int        `json:"ids,omitempty"`
+	Mode      TravelMode   `json:"mode"`
+	Distances [][]*float64 `json:"distances"`
+	Durations [][]*float64 `json:"durations"`
+	Source    string       `json:"source"`
+}
+
+// CorridorRequest - поиск достопримечательностей вдоль маршрута. Маршрут
+// задаётся списком точек или строкой в формате Encoded Polyline (точность 5 знаков).
+type CorridorRequest struct {
+	Polyline        []Location `json:"polyline"`
+	EncodedPolyline string     `json:"encoded_polyline"`
+	// BufferKm - наибольшее расстояние от маршрута в километрах.
+	BufferKm   float64  `json:"buffer_km"`
+	Categories []string `json:"categories"`
+}
+
+// CorridorLandmark - достопримечательность рядом с маршрутом. Distance - расстояние
+// до маршрута, DistanceAlong - расстояние от начала маршрута до ближайшей точки на нём (м).
+type CorridorLandmark struct {
+	Landmark
+	DistanceAlong float64 `json:"distance_along"`
+}
@@ -0,0 +1,51 @@
+package models
+
+// Типы сущностей и операций журнала изменений.
+const (
+	SyncEntityLandmark = "landmark"
+	SyncEntityImage    = "image"
+	SyncEntityCategory = "category"
+
+	SyncOpInsert = "insert"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+// Change - запись журнала изменений.
+type Change struct {
+	ID int64
+	// TxID - номер транзакции, в которой сделано изменение.
+	TxID   int64
+	Entity string
+	Key    string
+	Op     string
+}
+
+// SyncCursor - позиция в журнале изменений. Записи упорядочены по номеру
+// транзакции и номеру записи внутри неё.
+type SyncCursor struct {
+	TxID int64
+	ID   int64
+}
+
+// SyncResponse - изменения с момента курсора since. Cursor передаётся в
+// следующий запрос; HasMore означает, что изменения получены не полностью.
+type SyncResponse struct {
+	Cursor     string        `json:"cursor"`
+	HasMore    bool          `json:"has_more"`
+	Landmarks  SyncLandmarks `json:"landmarks"`
+	Images     SyncKeys      `json:"images"`
+	Categories SyncKeys      `json:"categories"`
+}
+
+type SyncLandmarks struct {
+	Created []Landmark `json:"created"`
+	Updated []Landmark `json:"updated"`
+	Deleted []int      `json:"deleted"`
+}
+
+type SyncKeys struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+}
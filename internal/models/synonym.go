@@ -0,0 +1,8 @@
+package models
+
+// Synonym - запись словаря синонимов поиска: термин и его альтернативные названия.
+type Synonym struct {
+	ID       int      `json:"id"`
+	Term     string   `json:"term"`
+	Synonyms []string `json:"synonyms"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// Уровни сложности тропы.
+const (
+	TrailDifficultyEasy     = "easy"
+	TrailDifficultyModerate = "moderate"
+	TrailDifficultyHard     = "hard"
+)
+
+// Trail - пешеходная тропа. Длина и набор высоты в метрах, время в минутах.
+type Trail struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	Slug          string   `json:"slug"`
+	Description   string   `json:"description"`
+	Difficulty    string   `json:"difficulty"`
+	Length        float64  `json:"length"`
+	ElevationGain float64  `json:"elevation_gain"`
+	Duration      int      `json:"duration"`
+	Geometry      Geometry `json:"-"`
+}
+
+// TrailImport - данные тропы, которые не содержатся в GPX-файле.
+// Если Difficulty не задана, она вычисляется по длине и набору высоты.
+type TrailImport struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Difficulty  string `json:"difficulty"`
+}
+
+// TrackPoint - точка трека с необязательной высотой.
+type TrackPoint struct {
+	Location
+	Elevation *float64
+}
@@ -7,6 +7,7 @@ type User struct {
 	Username     string    `json:"username" db:"username"`
 	Email        string    `json:"email,omitempty" db:"email"`
 	PasswordHash string    `json:"password_hash,omitempty" db:"password_hash"`
+	IsAdmin      bool      `json:"-" db:"is_admin"`
 	Created_at   time.Time `json:"created_at,omitempty" db:"created_at"`
 	Updated_at   time.Time `json:"updated_at,omitempty" db:"updated_at"`
 }
@@ -19,6 +19,28 @@ type WeatherResponse struct {
 	Rain        float64   `json:"rain"`
 	WindSpeed   float64   `json:"wind_speed"`
 	WindDegree  float64   `json:"wind_degree"`
+	FeelsLike   float64   `json:"feels_like"` // Ощущаемая температура (°C)
+	Humidity    int       `json:"humidity"`   // Влажность (%)
+	Pressure    int       `json:"pressure"`   // Давление (гПа)
+	Pop         float64   `json:"pop"`        // Вероятность осадков (0–1)
+	Clouds      int       `json:"clouds"`     // Облачность (%)
+	WindGust    float64   `json:"wind_gust"`  // Скорость порывов ветра (м/с)
+	Visibility  int       `json:"visibility"` // Видимость (м)
+	Pod         string    `json:"pod"`        // Часть дня: "d" (день) или "n" (ночь)
+}
+
+// DailyWeather - сводка прогноза за сутки по местному времени.
+type DailyWeather struct {
+	LandmarkID   int     `json:"landmark_id"`
+	Date         string  `json:"date"` // Дата в формате YYYY-MM-DD
+	TempMin      float64 `json:"temp_min"`
+	TempMax      float64 `json:"temp_max"`
+	Description  string  `json:"description"` // Преобладающие погодные условия
+	Icon         string  `json:"icon"`
+	Rain         float64 `json:"rain"`           // Сумма осадков (мм)
+	WindSpeedMax float64 `json:"wind_speed_max"` // Наибольшая скорость ветра (м/с)
+	PopMax       float64 `json:"pop_max"`        // Наибольшая вероятность осадков (0–1)
+	Slots        int     `json:"slots"`          // Число интервалов прогноза за сутки
 }
 
 // Forecast описывает прогноз для одного 3-часового интервала.
@@ -95,3 +117,97 @@ type Coord struct {
 	Lat float64 `json:"lat"` // Широта
 	Lon float64 `json:"lon"` // Долгота
 }
+
+// LocationForecast - прогноз погоды в точке, не зависящий от поставщика данных.
+type LocationForecast struct {
+	Provider string `json:"provider"`
+	// Timezone - смещение местного времени от UTC в секундах.
+	Timezone int            `json:"timezone"`
+	Sunrise  time.Time      `json:"sunrise"`
+	Sunset   time.Time      `json:"sunset"`
+	Slots    []ForecastSlot `json:"slots"`
+}
+
+// ForecastSlot - прогноз на один интервал времени (обычно 3 часа).
+type ForecastSlot struct {
+	Date        time.Time `json:"date"`        // Начало интервала
+	Temperature float64   `json:"temperature"` // Температура (°C)
+	FeelsLike   float64   `json:"feels_like"`  // Ощущаемая температура (°C)
+	Humidity    int       `json:"humidity"`    // Влажность (%)
+	Pressure    int       `json:"pressure"`    // Давление (гПа)
+	Pop         float64   `json:"pop"`         // Вероятность осадков (0–1)
+	Clouds      int       `json:"clouds"`      // Облачность (%)
+	WindSpeed   float64   `json:"wind_speed"`  // Скорость ветра (м/с)
+	WindDegree  int       `json:"wind_degree"` // Направление ветра (градусы)
+	WindGust    float64   `json:"wind_gust"`   // Скорость порывов ветра (м/с)
+	Visibility  int       `json:"visibility"`  // Видимость (м)
+	Rain        float64   `json:"rain"`        // Осадки за интервал (мм)
+	Description string    `json:"description"` // Описание погоды
+	Icon        string    `json:"icon"`        // Код иконки в формате OpenWeatherMap
+	Pod         string    `json:"pod"`         // Часть дня: "d" (день) или "n" (ночь)
+}
+
+// WeatherRefreshStats - итоги обновления прогнозов погоды.
+type WeatherRefreshStats struct {
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	Landmarks   int       `json:"landmarks"`    // Всего достопримечательностей
+	Cells       int       `json:"cells"`        // Ячеек сетки, т. е. запросов к поставщику
+	FailedCells int       `json:"failed_cells"` // Ячеек, для которых прогноз не получен
+	Updated     int       `json:"updated"`      // Достопримечательностей с обновлённым прогнозом
+}
+
+// WeatherRollupStats - итоги сведения прошедших прогнозов в климатическую статистику.
+type WeatherRollupStats struct {
+	Before time.Time `json:"before"` // Сведены интервалы раньше этого момента
+	Purged int       `json:"purged"` // Удалено строк прогноза
+	Months int       `json:"months"` // Обновлено записей статистики (достопримечательность и месяц)
+}
+
+// ClimateMonth - типичная погода месяца по накопленным прогнозам.
+type ClimateMonth struct {
+	Month            int     `json:"month"`
+	AvgTemperature   float64 `json:"avg_temperature"`   // Средняя температура (°C)
+	AvgHigh          float64 `json:"avg_high"`          // Средний дневной максимум (°C)
+	AvgLow           float64 `json:"avg_low"`           // Средний дневной минимум (°C)
+	AvgWindSpeed     float64 `json:"avg_wind_speed"`    // Средняя скорость ветра (м/с)
+	AvgPrecipitation float64 `json:"avg_precipitation"` // Осадки в среднем за сутки (мм)
+	RainyDayShare    float64 `json:"rainy_day_share"`   // Доля дней с осадками (0–1)
+	Days             int     `json:"days"`              // Число дней в статистике
+}
+
+// Climate - климатическая статистика достопримечательности по месяцам.
+type Climate struct {
+	LandmarkID int            `json:"landmark_id"`
+	Months     []ClimateMonth `json:"months"`
+}
+
+// SlotScore - оценка интервала прогноза для посещения места на открытом воздухе.
+// Составляющие оценки лежат в диапазоне от 0 до 1.
+type SlotScore struct {
+	Date        time.Time `json:"date"`
+	Score       float64   `json:"score"`
+	Dry         float64   `json:"dry"`      // 1 - вероятность осадков
+	Calm        float64   `json:"calm"`     // Отсутствие сильного ветра
+	Comfort     float64   `json:"comfort"`  // Комфортность температуры
+	Daylight    float64   `json:"daylight"` // 1 днём, 0 ночью
+	Temperature float64   `json:"temperature"`
+	Pop         float64   `json:"pop"`
+	WindSpeed   float64   `json:"wind_speed"`
+	Description string    `json:"description"`
+	Icon        string    `json:"icon"`
+}
+
+// BestTimeWindow - непрерывный промежуток из одного или нескольких интервалов прогноза.
+type BestTimeWindow struct {
+	Start time.Time   `json:"start"`
+	End   time.Time   `json:"end"`
+	Score float64     `json:"score"` // Средняя оценка интервалов
+	Slots []SlotScore `json:"slots"`
+}
+
+// BestTime - лучшие промежутки для посещения в пределах прогноза, начиная с лучшего.
+type BestTime struct {
+	LandmarkID int              `json:"landmark_id"`
+	Windows    []BestTimeWindow `json:"windows"`
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"trailblazer/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type CheckInDB struct {
+	ctx      context.Context
+	postgres *sqlx.DB
+}
+
+func NewCheckInPostgres(ctx context.Context, db *sqlx.DB) *CheckInDB {
+	return &CheckInDB{ctx: ctx, postgres: db}
+}
+
+func (c *CheckInDB) AddCheckIn(checkIn models.CheckIn) (models.CheckIn, error) {
+	query := `
+		INSERT INTO checkins(user_id, landmark_id, location, distance)
+		VALUES ($1, $2, ST_SetSRID(ST_MakePoint($3, $4), 4326)::geography, $5)
+		RETURNING id, created_at
+	`
+	err := c.postgres.QueryRowContext(c.ctx, query, checkIn.UserID, checkIn.LandmarkID,
+		checkIn.Location.Lng, checkIn.Location.Lat, checkIn.Distance).Scan(&checkIn.ID, &checkIn.CreatedAt)
+	if err != nil {
+		return models.CheckIn{}, fmt.Errorf("failed to add check-in: %w", err)
+	}
+	return checkIn, nil
+}
+
+func (c *CheckInDB) GetVisits(userID int64) ([]models.Visit, error) {
+	query := `
+		SELECT
+			landmark.id,
+			landmark.name,
+			landmark.address,
+			landmark.category,
+			landmark.description,
+			landmark.history,
+			ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
+			landmark.images_name,
+			count(*),
+			min(checkins.created_at),
+			max(checkins.created_at)
+		FROM checkins
+		JOIN landmark ON landmark.id = checkins.landmark_id
+		WHERE checkins.user_id = $1
+		GROUP BY landmark.id
+		ORDER BY max(checkins.created_at) DESC
+	`
+	rows, err := c.postgres.QueryContext(c.ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visits: %w", err)
+	}
+	defer rows.Close()
+
+	visits := make([]models.Visit, 0)
+	for rows.Next() {
+		var v models.Visit
+		err := rows.Scan(&v.Landmark.ID, &v.Landmark.Name, &v.Landmark.Address, &v.Landmark.Category, &v.Landmark.Description,
+			&v.Landmark.History, &v.Landmark.Lng, &v.Landmark.Lat, &v.Landmark.ImagePath, &v.CheckIns, &v.FirstVisitedAt, &v.LastVisitedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get visits: %w", err)
+		}
+		v.Landmark.TranslatedName = strings.Split(v.Landmark.ImagePath, ".")[0]
+		visits = append(visits, v)
+	}
+	return visits, rows.Err()
+}
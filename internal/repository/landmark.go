@@ -3,13 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"trailblazer/internal/models"
-	"trailblazer/internal/utils"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type LandmarkDB struct {
@@ -29,14 +31,28 @@ func (l *LandmarkDB) GetFacilities(bbox models.BBOX) ([]models.Landmark, error)
 			landmark.id,
 			landmark.name,
 			landmark.address,
-			st_astext(landmark.location) as loc,
+			landmark.category,
+			ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
 			landmark.images_name
 		FROM landmark        
-		LEFT JOIN public.weather w on landmark.id = w.landmark_id
 		
-	  	WHERE ST_Intersects(ST_MakeEnvelope($1,$2,$3,$4,4326 ), landmark.location::geometry)
+	  	WHERE %s
 	  `
-	rows, err := l.postgres.Query(selectQuery, bbox.SW.Lng, bbox.SW.Lat, bbox.NE.Lng, bbox.NE.Lat)
+	where := "ST_Intersects(ST_MakeEnvelope($1,$2,$3,$4,4326 ), landmark.location::geometry)"
+	args := []any{bbox.SW.Lng, bbox.SW.Lat, bbox.NE.Lng, bbox.NE.Lat}
+	switch {
+	case bbox.Area != nil:
+		area, err := json.Marshal(bbox.Area)
+		if err != nil {
+			return []models.Landmark{}, err
+		}
+		where = "ST_Intersects(ST_MakeValid(ST_SetSRID(ST_GeomFromGeoJSON($1), 4326)), landmark.location::geometry)"
+		args = []any{string(area)}
+	case bbox.Center != nil:
+		where = "ST_DWithin(landmark.location, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)"
+		args = []any{bbox.Center.Lng, bbox.Center.Lat, bbox.Radius}
+	}
+	rows, err := l.postgres.Query(fmt.Sprintf(selectQuery, where), args...)
 	if err != nil {
 		return []models.Landmark{}, err
 	}
@@ -44,14 +60,13 @@ func (l *LandmarkDB) GetFacilities(bbox models.BBOX) ([]models.Landmark, error)
 	defer rows.Close()
 	var facilities []models.Landmark
 	for rows.Next() {
-		f, p := models.Landmark{}, ""
-		err := rows.Scan(&f.ID, &f.Name, &f.Address, &p, &f.ImagePath)
+		var f models.Landmark
+		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Lng, &f.Lat, &f.ImagePath)
 		if err != nil {
 			return []models.Landmark{}, err
 		}
 
 		f.TranslatedName = strings.Split(f.ImagePath, ".")[0]
-		f.Location = utils.LocationFromPoint(p)
 		facilities = append(facilities, f)
 	}
 	return facilities, nil
@@ -68,7 +83,7 @@ func (l *LandmarkDB) GetLandmarks(page int, categories []string) ([]models.Landm
 				landmark.category,
 				landmark.description,
 				landmark.history,
-				st_astext(landmark.location) as loc		,
+				ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat		,
 				landmark.images_name
 			    FROM landmark
 			
@@ -83,7 +98,7 @@ func (l *LandmarkDB) GetLandmarks(page int, categories []string) ([]models.Landm
 			landmark.category,
 			landmark.description,
 			landmark.history,
-			st_astext(landmark.location) AS loc,
+			ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
 			landmark.images_name
 		FROM landmark
 		
@@ -105,15 +120,14 @@ func (l *LandmarkDB) GetLandmarks(page int, categories []string) ([]models.Landm
 	defer rows.Close()
 	var landmarks []models.Landmark
 	for rows.Next() {
-		f, p := models.Landmark{}, ""
-		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &p, &f.ImagePath)
+		var f models.Landmark
+		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &f.Lng, &f.Lat, &f.ImagePath)
 		if err != nil {
 			return []models.Landmark{}, err
 
 		}
 		f.TranslatedName = strings.Split(f.ImagePath, ".")[0]
 
-		f.Location = utils.LocationFromPoint(p)
 		landmarks = append(landmarks, f)
 	}
 	return landmarks, nil
@@ -139,7 +153,7 @@ func (l *LandmarkDB) GetLandmarksByIDs(ids []any) ([]models.Landmark, error) {
 			landmark.category,
 			landmark.description,
 			landmark.history,
-			ST_AsText(landmark.location) AS loc,
+			ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
 			landmark.images_name
 		FROM landmark
 		WHERE id IN (%s)
@@ -152,23 +166,97 @@ func (l *LandmarkDB) GetLandmarksByIDs(ids []any) ([]models.Landmark, error) {
 
 	var landmarks []models.Landmark
 	for res.Next() {
-		f, p := models.Landmark{}, ""
-		err := res.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &p, &f.ImagePath)
+		var f models.Landmark
+		err := res.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &f.Lng, &f.Lat, &f.ImagePath)
 		if err != nil {
 			return []models.Landmark{}, err
 
 		}
 
 		f.TranslatedName = strings.Split(f.ImagePath, ".")[0]
-		f.Location = utils.LocationFromPoint(p)
 		landmarks = append(landmarks, f)
 	}
 	return landmarks, nil
 }
 
-func (l *LandmarkDB) Search(q string) ([]models.Landmark, error) {
-	landmarksMap := make(map[int]models.Landmark)
+// Search ищет достопримечательности по фразам. distanceScale - расстояние в
+// километрах, на котором релевантность уменьшается вдвое при переданной точке.
+func (l *LandmarkDB) Search(phrases []string, origin *models.Location, distanceScale float64) ([]models.Landmark, error) {
+	q := tsQueryFromPhrases(phrases)
+	if q == "" {
+		return []models.Landmark{}, nil
+	}
+	query := `
+		SELECT id, name, address, category, description, history, lng, lat, images_name, distance
+		FROM (
+			SELECT
+				landmark.id,
+				landmark.name,
+				landmark.address,
+				landmark.category,
+				landmark.description,
+				landmark.history,
+				ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
+				landmark.images_name,
+				CASE WHEN $2::float8 IS NULL OR $3::float8 IS NULL THEN NULL
+					ELSE ST_Distance(landmark.location, ST_SetSRID(ST_MakePoint($2, $3), 4326)::geography)
+				END AS distance,
+				2 * ts_rank(to_tsvector('russian', landmark.name), to_tsquery('russian', $1)) +
+					ts_rank(to_tsvector('russian', coalesce(landmark.address, '')), to_tsquery('russian', $1)) AS rank
+			FROM landmark
+			WHERE to_tsvector('russian', landmark.name) @@ to_tsquery('russian', $1)
+			   OR to_tsvector('russian', landmark.address) @@ to_tsquery('russian', $1)
+		) AS found
+		ORDER BY rank / (1 + coalesce(distance, 0) / 1000 / $4::float8) DESC, id
+	`
+	var lng, lat any
+	if origin != nil {
+		lng, lat = origin.Lng, origin.Lat
+	}
+	rows, err := l.postgres.Query(query, q, lng, lat, distanceScale)
+	if err != nil {
+		return []models.Landmark{}, err
+	}
+	defer rows.Close()
+
+	landmarks := make([]models.Landmark, 0)
+	for rows.Next() {
+		var f models.Landmark
+		var distance sql.NullFloat64
+		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &f.Lng, &f.Lat, &f.ImagePath, &distance)
+		if err != nil {
+			return []models.Landmark{}, err
+		}
+		f.TranslatedName = strings.Split(f.ImagePath, ".")[0]
+		if distance.Valid {
+			f.Distance = &distance.Float64
+		}
+		landmarks = append(landmarks, f)
+	}
+	if err = rows.Err(); err != nil {
+		return []models.Landmark{}, err
+	}
+
+	return landmarks, nil
+}
+
+// tsQueryFromPhrases собирает выражение для to_tsquery: слова одной фразы
+// объединяются через &, а сами фразы - через |.
+func tsQueryFromPhrases(phrases []string) string {
+	parts := make([]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		words := strings.FieldsFunc(phrase, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+		parts = append(parts, "("+strings.Join(words, " & ")+")")
+	}
+	return strings.Join(parts, " | ")
+}
 
+func (l *LandmarkDB) GetNearby(q models.NearbyQuery) ([]models.Landmark, error) {
 	query := `
 		SELECT
 			landmark.id,
@@ -177,33 +265,56 @@ func (l *LandmarkDB) Search(q string) ([]models.Landmark, error) {
 			landmark.category,
 			landmark.description,
 			landmark.history,
-			st_astext(landmark.location) as loc,
-			landmark.images_name
-		FROM landmark
-		WHERE to_tsvector('russian', landmark.name) @@ to_tsquery('russian', $1)
+			ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
+			landmark.images_name,
+			ST_Distance(landmark.location, point.geog) AS distance
+		FROM landmark,
+			(SELECT ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography AS geog) AS point
+		WHERE ST_DWithin(landmark.location, point.geog, $3)
+		  AND landmark.id <> $4
+		  AND (cardinality($5::text[]) = 0 OR lower(landmark.category) = ANY($5))
+		ORDER BY distance
+		LIMIT $6
 	`
-	rows, err := l.postgres.Query(query, q)
+	categories := make([]string, len(q.Categories))
+	for i, category := range q.Categories {
+		categories[i] = strings.ToLower(strings.TrimSpace(category))
+	}
+	rows, err := l.postgres.Query(query, q.Lng, q.Lat, q.Radius, q.ExcludeID, pq.Array(categories), q.Limit)
 	if err != nil {
 		return []models.Landmark{}, err
 	}
 	defer rows.Close()
 
+	landmarks := make([]models.Landmark, 0)
 	for rows.Next() {
 		var f models.Landmark
-		var p string
-		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &p, &f.ImagePath)
+		var distance float64
+		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &f.Lng, &f.Lat, &f.ImagePath, &distance)
 		if err != nil {
 			return []models.Landmark{}, err
 		}
 		f.TranslatedName = strings.Split(f.ImagePath, ".")[0]
-		f.Location = utils.LocationFromPoint(p)
-		landmarksMap[f.ID] = f
+		f.Distance = &distance
+		landmarks = append(landmarks, f)
 	}
 	if err = rows.Err(); err != nil {
 		return []models.Landmark{}, err
 	}
+	return landmarks, nil
+}
 
-	query = `
+// GetCorridor возвращает достопримечательности не дальше buffer метров от линии path
+// в порядке их проекций на линию.
+func (l *LandmarkDB) GetCorridor(path []models.Location, buffer float64, categories []string) ([]models.CorridorLandmark, error) {
+	coords := make([]string, len(path))
+	for i, p := range path {
+		coords[i] = fmt.Sprintf("%f %f", p.Lng, p.Lat)
+	}
+	query := `
+		WITH route AS (
+			SELECT ST_GeomFromText($1, 4326) AS geom
+		)
 		SELECT
 			landmark.id,
 			landmark.name,
@@ -211,43 +322,66 @@ func (l *LandmarkDB) Search(q string) ([]models.Landmark, error) {
 			landmark.category,
 			landmark.description,
 			landmark.history,
-			st_astext(landmark.location) as loc,
-			landmark.images_name
-		FROM landmark
-		WHERE to_tsvector('russian', landmark.address) @@ to_tsquery('russian', $1)
+			ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
+			landmark.images_name,
+			ST_Distance(landmark.location, route.geom::geography) AS distance,
+			ST_LineLocatePoint(route.geom, landmark.location::geometry) * ST_Length(route.geom::geography) AS distance_along
+		FROM landmark, route
+		WHERE ST_DWithin(landmark.location, route.geom::geography, $2)
+		  AND (cardinality($3::text[]) = 0 OR lower(landmark.category) = ANY($3))
+		ORDER BY distance_along
 	`
-	rows, err = l.postgres.Query(query, q)
+	lowered := make([]string, len(categories))
+	for i, category := range categories {
+		lowered[i] = strings.ToLower(strings.TrimSpace(category))
+	}
+	rows, err := l.postgres.Query(query, "LINESTRING("+strings.Join(coords, ",")+")", buffer, pq.Array(lowered))
 	if err != nil {
-		return []models.Landmark{}, err
+		return []models.CorridorLandmark{}, err
 	}
 	defer rows.Close()
 
+	landmarks := make([]models.CorridorLandmark, 0)
 	for rows.Next() {
-		var f models.Landmark
-		var p string
-		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &p, &f.ImagePath)
+		var f models.CorridorLandmark
+		var distance float64
+		err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &f.Lng, &f.Lat, &f.ImagePath, &distance, &f.DistanceAlong)
 		if err != nil {
-			return []models.Landmark{}, err
-		}
-		_, ok := landmarksMap[f.ID]
-		if ok {
-			continue
+			return []models.CorridorLandmark{}, err
 		}
-
 		f.TranslatedName = strings.Split(f.ImagePath, ".")[0]
-		f.Location = utils.LocationFromPoint(p)
-		landmarksMap[f.ID] = f
+		f.Distance = &distance
+		landmarks = append(landmarks, f)
 	}
 	if err = rows.Err(); err != nil {
-		return []models.Landmark{}, err
+		return []models.CorridorLandmark{}, err
 	}
+	return landmarks, nil
+}
 
-	landmarks := make([]models.Landmark, 0, len(landmarksMap))
-	for _, landmark := range landmarksMap {
-		landmarks = append(landmarks, landmark)
+// GetTile возвращает векторный тайл Mapbox Vector Tile со слоем landmarks.
+func (l *LandmarkDB) GetTile(z, x, y int) ([]byte, error) {
+	query := `
+		WITH bounds AS (
+			SELECT ST_TileEnvelope($1, $2, $3) AS geom
+		),
+		mvtgeom AS (
+			SELECT
+				ST_AsMVTGeom(ST_Transform(landmark.location::geometry, 3857), bounds.geom) AS geom,
+				landmark.id,
+				landmark.name,
+				landmark.category,
+				split_part(landmark.images_name, '.', 1) AS slug
+			FROM landmark, bounds
+			WHERE landmark.location::geometry && ST_Transform(bounds.geom, 4326)
+		)
+		SELECT ST_AsMVT(mvtgeom.*, 'landmarks', 4096, 'geom', 'id') FROM mvtgeom
+	`
+	var tile []byte
+	if err := l.postgres.QueryRow(query, z, x, y).Scan(&tile); err != nil {
+		return nil, err
 	}
-
-	return landmarks, nil
+	return tile, nil
 }
 
 func (l *LandmarkDB) UpdateImagePath(place string, path string) error {
@@ -262,17 +396,15 @@ func (l *LandmarkDB) UpdateImagePath(place string, path string) error {
 func (l *LandmarkDB) GetLandmarksByName(name string) (models.Landmark, error) {
 	query :=
 		`
-		SELECT id,name, address,category,description,history,st_astext(landmark.location) as loc,images_name FROM landmark
+		SELECT id,name, address,category,description,history,ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,images_name FROM landmark
 		WHERE SUBSTRING(images_name FROM 1 FOR POSITION('.' IN images_name) ) LIKE $1 || '%';
 		`
 	res := l.postgres.QueryRow(query, name)
 	landmark := models.Landmark{}
-	var tmp string
-	if err := res.Scan(&landmark.ID, &landmark.Name, &landmark.Address, &landmark.Category, &landmark.Description, &landmark.History, &tmp, &landmark.ImagePath); err != nil {
+	if err := res.Scan(&landmark.ID, &landmark.Name, &landmark.Address, &landmark.Category, &landmark.Description, &landmark.History, &landmark.Lng, &landmark.Lat, &landmark.ImagePath); err != nil {
 		return models.Landmark{}, err
 	}
 	landmark.TranslatedName = strings.Split(landmark.ImagePath, ".")[0]
-	landmark.Location = utils.LocationFromPoint(tmp)
 	return landmark, nil
 }
 
@@ -296,7 +428,7 @@ func (l *LandmarkDB) GetLandmarksByCategories(categories []string) ([]models.Lan
 			landmark.category,
 			landmark.description,
 			landmark.history,
-			st_astext(landmark.location) AS loc,
+			ST_X(landmark.location::geometry) AS lng, ST_Y(landmark.location::geometry) AS lat,
 			landmark.images_name
 		FROM landmark
 		WHERE landmark.category IN (%s)
@@ -311,12 +443,10 @@ func (l *LandmarkDB) GetLandmarksByCategories(categories []string) ([]models.Lan
 	var landmarks []models.Landmark
 	for rows.Next() {
 		var f models.Landmark
-		var loc string
-		if err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &loc, &f.ImagePath); err != nil {
+		if err := rows.Scan(&f.ID, &f.Name, &f.Address, &f.Category, &f.Description, &f.History, &f.Lng, &f.Lat, &f.ImagePath); err != nil {
 			return nil, err
 		}
 		f.TranslatedName = strings.Split(f.ImagePath, ".")[0]
-		f.Location = utils.LocationFromPoint(loc)
 		landmarks = append(landmarks, f)
 	}
 
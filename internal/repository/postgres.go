@@ -64,10 +64,18 @@ func NewPostgresRepository(ctx context.Context, cfg config.DatabaseConfig) (*Rep
 	userDb := NewUserPostgres(ctx, db)
 	landmarkDB := NewLandmarkPostgres(ctx, db)
 	weatherDB := NewWeatherPostgres(ctx, db)
+	synonymDB := NewSynonymPostgres(ctx, db)
+	trailDB := NewTrailPostgres(ctx, db)
+	checkInDB := NewCheckInPostgres(ctx, db)
+	syncDB := NewSyncPostgres(ctx, db)
 	repository := &Repository{
 		User:     userDb,
 		Landmark: landmarkDB,
 		Weather:  weatherDB,
+		Synonym:  synonymDB,
+		Trail:    trailDB,
+		CheckIn:  checkInDB,
+		Sync:     syncDB,
 	}
 	return repository, nil
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"trailblazer/internal/models"
 )
@@ -13,23 +14,54 @@ type User interface {
 	GetProfile(ctx context.Context, userID int64) (*models.Profile, error)
 	AddReview(review models.Review) error
 	GetReview(name string, onlyPhoto bool) (map[int]models.ReviewByUser, error)
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
 }
 
 type Landmark interface {
 	GetFacilities(bbox models.BBOX) ([]models.Landmark, error)
 	GetLandmarks(page int, categories []string) ([]models.Landmark, error)
 	GetLandmarksByIDs(ids []any) ([]models.Landmark, error)
-	Search(q string) ([]models.Landmark, error)
+	Search(phrases []string, origin *models.Location, distanceScale float64) ([]models.Landmark, error)
 	UpdateImagePath(place string, path string) error
 	GetLandmarksByName(name string) (models.Landmark, error)
 	GetLandmarksByCategories(categories []string) ([]models.Landmark, error)
+	GetNearby(q models.NearbyQuery) ([]models.Landmark, error)
+	GetTile(z, x, y int) ([]byte, error)
+	GetCorridor(path []models.Location, buffer float64, categories []string) ([]models.CorridorLandmark, error)
 }
 type Weather interface {
-	SetWeather(id int, forecast models.WeatherForecast) error
+	SetWeather(id int, forecast models.LocationForecast) error
 	GetWeatherByLandmarkID(id int) (*[]models.WeatherResponse, error)
+	GetWeatherByLandmarkIDs(ids []int) (map[int][]models.WeatherResponse, error)
+	RollupWeather(before time.Time, timezone string, rainyThreshold float64) (int, int, error)
+	GetClimate(landmarkID int) ([]models.ClimateMonth, error)
+}
+type Synonym interface {
+	GetSynonyms() ([]models.Synonym, error)
+	AddSynonym(synonym models.Synonym) (int, error)
+	UpdateSynonym(synonym models.Synonym) error
+	DeleteSynonym(id int) error
+}
+type Trail interface {
+	AddTrail(trail models.Trail, path []models.Location) (int, error)
+	GetTrailBySlug(slug string) (models.Trail, error)
+	GetTrailsNearLandmark(landmarkID int, radius float64) ([]models.Trail, error)
+	GetTrailsInBBOX(bbox models.BBOX) ([]models.Trail, error)
+}
+type CheckIn interface {
+	AddCheckIn(checkIn models.CheckIn) (models.CheckIn, error)
+	GetVisits(userID int64) ([]models.Visit, error)
+}
+type Sync interface {
+	GetChanges(since models.SyncCursor, limit int) ([]models.Change, error)
+	GetVersion() (string, error)
 }
 type Repository struct {
 	User
 	Weather
 	Landmark
+	Synonym
+	Trail
+	CheckIn
+	Sync
 }
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"trailblazer/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type SyncDB struct {
+	ctx      context.Context
+	postgres *sqlx.DB
+}
+
+func NewSyncPostgres(ctx context.Context, db *sqlx.DB) *SyncDB {
+	return &SyncDB{ctx: ctx, postgres: db}
+}
+
+// GetChanges возвращает не более limit записей журнала после курсора since.
+// Записи транзакций, которые ещё могут выполняться, не возвращаются: иначе
+// запись с меньшим номером, зафиксированная позже, оказалась бы до курсора.
+func (s *SyncDB) GetChanges(since models.SyncCursor, limit int) ([]models.Change, error) {
+	query := `
+		SELECT id, txid::text, entity, entity_key, op FROM sync_changes
+		WHERE (txid, id) > ($1::text::xid8, $2)
+		  AND txid < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY txid, id
+		LIMIT $3
+	`
+	rows, err := s.postgres.QueryContext(s.ctx, query, since.TxID, since.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]models.Change, 0)
+	for rows.Next() {
+		var c models.Change
+		if err := rows.Scan(&c.ID, &c.TxID, &c.Entity, &c.Key, &c.Op); err != nil {
+			return nil, fmt.Errorf("failed to get changes: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// GetVersion возвращает строку, которая меняется при каждой новой записи журнала.
+func (s *SyncDB) GetVersion() (string, error) {
+	var maxID, count int64
+	query := `SELECT coalesce(max(id), 0), count(*) FROM sync_changes`
+	if err := s.postgres.QueryRowContext(s.ctx, query).Scan(&maxID, &count); err != nil {
+		return "", fmt.Errorf("failed to get data version: %w", err)
+	}
+	return fmt.Sprintf("%d-%d", maxID, count), nil
+}
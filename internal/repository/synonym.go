@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"trailblazer/internal/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type SynonymDB struct {
+	ctx      context.Context
+	postgres *sqlx.DB
+}
+
+func NewSynonymPostgres(ctx context.Context, db *sqlx.DB) *SynonymDB {
+	return &SynonymDB{ctx: ctx, postgres: db}
+}
+
+func (s *SynonymDB) GetSynonyms() ([]models.Synonym, error) {
+	query := `SELECT id, term, synonyms FROM search_synonyms ORDER BY term`
+	rows, err := s.postgres.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+	defer rows.Close()
+
+	synonyms := make([]models.Synonym, 0)
+	for rows.Next() {
+		var synonym models.Synonym
+		if err := rows.Scan(&synonym.ID, &synonym.Term, pq.Array(&synonym.Synonyms)); err != nil {
+			return nil, fmt.Errorf("failed to get synonyms: %w", err)
+		}
+		synonyms = append(synonyms, synonym)
+	}
+	return synonyms, rows.Err()
+}
+
+func (s *SynonymDB) AddSynonym(synonym models.Synonym) (int, error) {
+	query := `INSERT INTO search_synonyms(term, synonyms) VALUES ($1, $2) RETURNING id`
+	var id int
+	if err := s.postgres.QueryRow(query, synonym.Term, pq.Array(synonym.Synonyms)).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to add synonym: %w", err)
+	}
+	return id, nil
+}
+
+func (s *SynonymDB) UpdateSynonym(synonym models.Synonym) error {
+	query := `
+		UPDATE search_synonyms SET term = $2, synonyms = $3, updated_at = current_timestamp
+		WHERE id = $1
+		RETURNING id
+	`
+	var id int
+	err := s.postgres.QueryRow(query, synonym.ID, synonym.Term, pq.Array(synonym.Synonyms)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("synonym not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update synonym: %w", err)
+	}
+	return nil
+}
+
+func (s *SynonymDB) DeleteSynonym(id int) error {
+	res, err := s.postgres.Exec(`DELETE FROM search_synonyms WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete synonym: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("synonym not found")
+	}
+	return nil
+}
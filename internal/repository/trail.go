@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"trailblazer/internal/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ErrTrailExists возвращается при добавлении тропы с уже занятым slug.
+var ErrTrailExists = errors.New("trail already exists")
+
+// uniqueViolation - код ошибки Postgres при нарушении ограничения уникальности.
+const uniqueViolation = "23505"
+
+type TrailDB struct {
+	ctx      context.Context
+	postgres *sqlx.DB
+}
+
+func NewTrailPostgres(ctx context.Context, db *sqlx.DB) *TrailDB {
+	return &TrailDB{ctx: ctx, postgres: db}
+}
+
+const trailColumns = `
+	trails.id,
+	trails.name,
+	trails.slug,
+	coalesce(trails.description, ''),
+	trails.difficulty,
+	trails.length,
+	trails.elevation_gain,
+	trails.duration,
+	ST_AsGeoJSON(trails.path)
+`
+
+func (t *TrailDB) AddTrail(trail models.Trail, path []models.Location) (int, error) {
+	coords := make([]string, len(path))
+	for i, p := range path {
+		coords[i] = fmt.Sprintf("%f %f", p.Lng, p.Lat)
+	}
+	query := `
+		INSERT INTO trails(name, slug, description, difficulty, length, elevation_gain, duration, path)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, ST_GeogFromText($8))
+		RETURNING id
+	`
+	var id int
+	err := t.postgres.QueryRow(query, trail.Name, trail.Slug, trail.Description, trail.Difficulty, trail.Length,
+		trail.ElevationGain, trail.Duration, "SRID=4326;LINESTRING("+strings.Join(coords, ",")+")").Scan(&id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return 0, fmt.Errorf("%w: %s", ErrTrailExists, trail.Slug)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to add trail: %w", err)
+	}
+	return id, nil
+}
+
+func (t *TrailDB) GetTrailBySlug(slug string) (models.Trail, error) {
+	query := `SELECT ` + trailColumns + ` FROM trails WHERE slug = $1`
+	trail, err := scanTrail(t.postgres.QueryRow(query, slug))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Trail{}, fmt.Errorf("trail not found")
+	}
+	return trail, err
+}
+
+// GetTrailsNearLandmark возвращает тропы, проходящие не дальше radius метров от достопримечательности.
+func (t *TrailDB) GetTrailsNearLandmark(landmarkID int, radius float64) ([]models.Trail, error) {
+	query := `
+		SELECT ` + trailColumns + `
+		FROM trails
+		JOIN landmark ON landmark.id = $1
+		WHERE ST_DWithin(trails.path, landmark.location, $2)
+		ORDER BY ST_Distance(trails.path, landmark.location)
+	`
+	return t.queryTrails(query, landmarkID, radius)
+}
+
+func (t *TrailDB) GetTrailsInBBOX(bbox models.BBOX) ([]models.Trail, error) {
+	query := `
+		SELECT ` + trailColumns + `
+		FROM trails
+		WHERE ST_Intersects(ST_MakeEnvelope($1, $2, $3, $4, 4326), trails.path::geometry)
+		ORDER BY trails.name
+	`
+	return t.queryTrails(query, bbox.SW.Lng, bbox.SW.Lat, bbox.NE.Lng, bbox.NE.Lat)
+}
+
+func (t *TrailDB) queryTrails(query string, args ...any) ([]models.Trail, error) {
+	rows, err := t.postgres.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trails: %w", err)
+	}
+	defer rows.Close()
+
+	trails := make([]models.Trail, 0)
+	for rows.Next() {
+		trail, err := scanTrail(rows)
+		if err != nil {
+			return nil, err
+		}
+		trails = append(trails, trail)
+	}
+	return trails, rows.Err()
+}
+
+func scanTrail(row interface{ Scan(...any) error }) (models.Trail, error) {
+	var trail models.Trail
+	var geometry string
+	err := row.Scan(&trail.ID, &trail.Name, &trail.Slug, &trail.Description, &trail.Difficulty,
+		&trail.Length, &trail.ElevationGain, &trail.Duration, &geometry)
+	if err != nil {
+		return models.Trail{}, err
+	}
+	if err := json.Unmarshal([]byte(geometry), &trail.Geometry); err != nil {
+		return models.Trail{}, fmt.Errorf("failed to decode trail geometry: %w", err)
+	}
+	return trail, nil
+}
@@ -74,6 +74,18 @@ func (u *UserDB) AddUser(ctx context.Context, userData models.User) error {
 	return nil
 }
 
+func (u *UserDB) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	var isAdmin bool
+	err := u.postgres.GetContext(ctx, &isAdmin, `SELECT is_admin FROM users WHERE id = $1`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	return isAdmin, nil
+}
+
 func (u *UserDB) GetProfile(ctx context.Context, userID int64) (*models.Profile, error) {
 	query := `SELECT username, user_bio, avatar FROM profiles_users WHERE user_id = $1`
 	var profile models.Profile
@@ -155,7 +167,7 @@ func (u *UserDB) AddReview(review models.Review) error {
 			`
 		_, err = tx.Exec(query, reviewID, name, image)
 		if err != nil {
-			slog.Error(fmt.Sprintf("error with adding review: %w", err))
+			slog.Error(fmt.Sprintf("error with adding review: %v", err))
 		}
 	}
 	tx.Commit()
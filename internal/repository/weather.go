@@ -3,12 +3,12 @@ package repository
 import (
 	"context"
 	"fmt"
-	"log/slog"
 	"time"
 
 	"trailblazer/internal/models"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type WeatherDB struct {
@@ -20,57 +20,152 @@ func NewWeatherPostgres(ctx context.Context, db *sqlx.DB) *WeatherDB {
 	return &WeatherDB{ctx: ctx, postgres: db}
 }
 
-func (r *WeatherDB) SetWeather(id int, forecast models.WeatherForecast) error {
+func (r *WeatherDB) SetWeather(id int, forecast models.LocationForecast) error {
 	query := `	
-			INSERT INTO weather(landmark_id, date, temperature, description, icon,rain,wind_speed,wind_degree )
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-			ON CONFLICT (landmark_id,date) DO UPDATE SET temperature=$3, description=$4, icon=$5,rain=$6,wind_speed=$7,wind_degree=$8
+			INSERT INTO weather(landmark_id, date, temperature, description, icon,rain,wind_speed,wind_degree,
+			                    feels_like, humidity, pressure, pop, clouds, wind_gust, visibility, pod)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			ON CONFLICT (landmark_id,date) DO UPDATE SET temperature=$3, description=$4, icon=$5,rain=$6,wind_speed=$7,wind_degree=$8,
+				feels_like=$9, humidity=$10, pressure=$11, pop=$12, clouds=$13, wind_gust=$14, visibility=$15, pod=$16
 				`
-	for _, info := range forecast.List {
-		date := time.Unix(info.Dt, 0)
-		temperature := info.Main.Temp
-		var description, icon string
-		if len(info.Weather) > 0 {
-			description = info.Weather[0].Description
-			icon = info.Weather[0].Icon
-		}
-		var rain, windSpeed float64
-		var windDegree int
-		if info.Rain != nil {
-			rain = info.Rain.ThreeHour
-		}
-		windSpeed = info.Wind.Speed
-		windDegree = info.Wind.Deg
-
-		_, err := r.postgres.Exec(query, id, date, temperature, description, icon, rain, windSpeed, windDegree)
+	tx, err := r.postgres.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save weather: %w", err)
+	}
+	defer tx.Rollback()
+	for _, slot := range forecast.Slots {
+		_, err := tx.Exec(query, id, slot.Date, slot.Temperature, slot.Description, slot.Icon, slot.Rain, slot.WindSpeed, slot.WindDegree,
+			slot.FeelsLike, slot.Humidity, slot.Pressure, slot.Pop, slot.Clouds, slot.WindGust, slot.Visibility, slot.Pod)
 		if err != nil {
-			slog.Error(fmt.Sprintf("error with saving weather %v", err))
-			continue
+			return fmt.Errorf("failed to save weather for %s: %w", slot.Date, err)
 		}
-
 	}
-	return nil
+	return tx.Commit()
 }
 
 func (r *WeatherDB) GetWeatherByLandmarkID(id int) (*[]models.WeatherResponse, error) {
 	query := `
-			SELECT date,temperature,description,icon,rain,wind_speed,wind_degree FROM weather WHERE 
+			SELECT date,temperature,description,icon,rain,wind_speed,wind_degree,
+			       feels_like,humidity,pressure,pop,clouds,wind_gust,visibility,pod FROM weather WHERE 
 				landmark_id=$1 AND date>current_timestamp 
  			`
 	res, err := r.postgres.Query(query, id)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Close()
 	weatherList := make([]models.WeatherResponse, 0)
 	for res.Next() {
 		weather := new(models.WeatherResponse)
 		weather.LandmarkID = id
-		err = res.Scan(&weather.Date, &weather.Temperature, &weather.Description, &weather.Icon, &weather.Rain, &weather.WindSpeed, &weather.WindDegree)
+		err = res.Scan(&weather.Date, &weather.Temperature, &weather.Description, &weather.Icon, &weather.Rain, &weather.WindSpeed, &weather.WindDegree,
+			&weather.FeelsLike, &weather.Humidity, &weather.Pressure, &weather.Pop, &weather.Clouds, &weather.WindGust, &weather.Visibility, &weather.Pod)
 		if err != nil {
 			return nil, err
 		}
 		weatherList = append(weatherList, *weather)
 	}
-	return &weatherList, nil
+	return &weatherList, res.Err()
+}
+
+// GetWeatherByLandmarkIDs возвращает будущие прогнозы для нескольких
+// достопримечательностей одним запросом, сгруппированные по landmark_id.
+func (r *WeatherDB) GetWeatherByLandmarkIDs(ids []int) (map[int][]models.WeatherResponse, error) {
+	query := `
+			SELECT landmark_id,date,temperature,description,icon,rain,wind_speed,wind_degree,
+			       feels_like,humidity,pressure,pop,clouds,wind_gust,visibility,pod FROM weather WHERE
+				landmark_id = ANY($1) AND date>current_timestamp
+			ORDER BY landmark_id, date
+ 			`
+	rows, err := r.postgres.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	weather := make(map[int][]models.WeatherResponse, len(ids))
+	for rows.Next() {
+		var w models.WeatherResponse
+		err = rows.Scan(&w.LandmarkID, &w.Date, &w.Temperature, &w.Description, &w.Icon, &w.Rain, &w.WindSpeed, &w.WindDegree,
+			&w.FeelsLike, &w.Humidity, &w.Pressure, &w.Pop, &w.Clouds, &w.WindGust, &w.Visibility, &w.Pod)
+		if err != nil {
+			return nil, err
+		}
+		weather[w.LandmarkID] = append(weather[w.LandmarkID], w)
+	}
+	return weather, rows.Err()
+}
+
+// RollupWeather переносит интервалы прогноза раньше before в климатическую
+// статистику и удаляет их. Сутки считаются в часовом поясе timezone, дождливыми
+// считаются сутки с осадками не меньше rainyThreshold мм.
+func (r *WeatherDB) RollupWeather(before time.Time, timezone string, rainyThreshold float64) (int, int, error) {
+	query := `
+		WITH purged AS (
+			DELETE FROM weather WHERE date < $1
+			RETURNING landmark_id, date, temperature, rain, wind_speed
+		), days AS (
+			SELECT landmark_id,
+			       (date AT TIME ZONE $2)::date AS day,
+			       count(*) AS slots,
+			       sum(temperature) AS temp_sum,
+			       sum(wind_speed) AS wind_sum,
+			       max(temperature) AS high,
+			       min(temperature) AS low,
+			       sum(rain) AS rain
+			FROM purged
+			WHERE landmark_id IS NOT NULL
+			GROUP BY landmark_id, day
+		), months AS (
+			INSERT INTO weather_climate(landmark_id, month, slots, temp_sum, wind_sum, days, high_sum, low_sum, rain_sum, rainy_days)
+			SELECT landmark_id, extract(month FROM day)::smallint,
+			       sum(slots), sum(temp_sum), sum(wind_sum), count(*),
+			       sum(high), sum(low), sum(rain), count(*) FILTER (WHERE rain >= $3)
+			FROM days
+			GROUP BY landmark_id, extract(month FROM day)
+			ON CONFLICT (landmark_id, month) DO UPDATE SET
+				slots = weather_climate.slots + EXCLUDED.slots,
+				temp_sum = weather_climate.temp_sum + EXCLUDED.temp_sum,
+				wind_sum = weather_climate.wind_sum + EXCLUDED.wind_sum,
+				days = weather_climate.days + EXCLUDED.days,
+				high_sum = weather_climate.high_sum + EXCLUDED.high_sum,
+				low_sum = weather_climate.low_sum + EXCLUDED.low_sum,
+				rain_sum = weather_climate.rain_sum + EXCLUDED.rain_sum,
+				rainy_days = weather_climate.rainy_days + EXCLUDED.rainy_days
+			RETURNING 1
+		)
+		SELECT (SELECT count(*) FROM purged), (SELECT count(*) FROM months)
+	`
+	var purged, months int
+	if err := r.postgres.QueryRowContext(r.ctx, query, before, timezone, rainyThreshold).Scan(&purged, &months); err != nil {
+		return 0, 0, fmt.Errorf("failed to roll up weather: %w", err)
+	}
+	return purged, months, nil
+}
 
+// GetClimate возвращает климатическую статистику достопримечательности по месяцам.
+func (r *WeatherDB) GetClimate(landmarkID int) ([]models.ClimateMonth, error) {
+	query := `
+		SELECT month,
+		       temp_sum / slots, high_sum / days, low_sum / days,
+		       wind_sum / slots, rain_sum / days, rainy_days::float / days, days
+		FROM weather_climate
+		WHERE landmark_id = $1 AND slots > 0 AND days > 0
+		ORDER BY month
+	`
+	rows, err := r.postgres.QueryContext(r.ctx, query, landmarkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get climate: %w", err)
+	}
+	defer rows.Close()
+	months := make([]models.ClimateMonth, 0)
+	for rows.Next() {
+		var m models.ClimateMonth
+		err := rows.Scan(&m.Month, &m.AvgTemperature, &m.AvgHigh, &m.AvgLow,
+			&m.AvgWindSpeed, &m.AvgPrecipitation, &m.RainyDayShare, &m.Days)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get climate: %w", err)
+		}
+		months = append(months, m)
+	}
+	return months, rows.Err()
 }
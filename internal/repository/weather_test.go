@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"trailblazer/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// testDB подключается к базе из TEST_DATABASE_URL с применёнными миграциями.
+// RollupWeather удаляет все прогнозы до даты среза, поэтому база должна быть отдельной.
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestRollupWeather(t *testing.T) {
+	db := testDB(t)
+	var landmarkID int
+	err := db.QueryRow(`INSERT INTO landmark(name, location) VALUES ('rollup test', 'SRID=4326;POINT(34.1 44.95)') RETURNING id`).Scan(&landmarkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Exec(`DELETE FROM landmark WHERE id = $1`, landmarkID) })
+
+	repo := NewWeatherPostgres(context.Background(), db)
+	slot := func(utc string, temp, rain, wind float64) models.ForecastSlot {
+		date, err := time.Parse(time.DateTime, utc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return models.ForecastSlot{Date: date, Temperature: temp, Rain: rain, WindSpeed: wind}
+	}
+	before := time.Date(2001, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		slots      []models.ForecastSlot
+		wantPurged int
+		wantMonths int
+		want       []models.ClimateMonth
+	}{
+		{
+			name: "first rollup",
+			slots: []models.ForecastSlot{
+				slot("2001-01-15 06:00:00", 2, 0.5, 4),
+				slot("2001-01-15 09:00:00", 6, 1, 2),
+				// 01:00 1 февраля по местному времени.
+				slot("2001-01-31 22:00:00", -1, 0, 1),
+			},
+			wantPurged: 3,
+			wantMonths: 2,
+			want: []models.ClimateMonth{
+				{Month: 1, AvgTemperature: 4, AvgHigh: 6, AvgLow: 2, AvgWindSpeed: 3, AvgPrecipitation: 1.5, RainyDayShare: 1, Days: 1},
+				{Month: 2, AvgTemperature: -1, AvgHigh: -1, AvgLow: -1, AvgWindSpeed: 1, Days: 1},
+			},
+		},
+		{
+			name:       "accumulates into existing month",
+			slots:      []models.ForecastSlot{slot("2001-01-20 09:00:00", 10, 0, 6)},
+			wantPurged: 1,
+			wantMonths: 1,
+			want: []models.ClimateMonth{
+				{Month: 1, AvgTemperature: 6, AvgHigh: 8, AvgLow: 6, AvgWindSpeed: 4, AvgPrecipitation: 0.75, RainyDayShare: 0.5, Days: 2},
+				{Month: 2, AvgTemperature: -1, AvgHigh: -1, AvgLow: -1, AvgWindSpeed: 1, Days: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repo.SetWeather(landmarkID, models.LocationForecast{Slots: tt.slots}); err != nil {
+				t.Fatal(err)
+			}
+			purged, months, err := repo.RollupWeather(before, "Etc/GMT-3", 1.0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if purged != tt.wantPurged || months != tt.wantMonths {
+				t.Errorf("RollupWeather() = %d purged, %d months, want %d, %d", purged, months, tt.wantPurged, tt.wantMonths)
+			}
+			got, err := repo.GetClimate(landmarkID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetClimate() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if !climateMonthEqual(got[i], tt.want[i]) {
+					t.Errorf("month %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func climateMonthEqual(a, b models.ClimateMonth) bool {
+	near := func(x, y float64) bool { return math.Abs(x-y) < 1e-9 }
+	return a.Month == b.Month && a.Days == b.Days &&
+		near(a.AvgTemperature, b.AvgTemperature) && near(a.AvgHigh, b.AvgHigh) && near(a.AvgLow, b.AvgLow) &&
+		near(a.AvgWindSpeed, b.AvgWindSpeed) && near(a.AvgPrecipitation, b.AvgPrecipitation) &&
+		near(a.RainyDayShare, b.RainyDayShare)
+}
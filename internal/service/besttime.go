@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+// Параметры подбора лучшего времени посещения.
+const (
+	BestTimeHorizon      = 5 * 24 * time.Hour
+	DefaultBestTimeLimit = 3
+	MaxBestTimeLimit     = 10
+	// BestTimeMinScore - наименьшая оценка интервала, пригодного для посещения.
+	BestTimeMinScore = 0.6
+
+	// forecastSlotDuration - длина интервала прогноза.
+	forecastSlotDuration = 3 * time.Hour
+	// nightFactor - множитель оценки для ночных интервалов.
+	nightFactor = 0.2
+)
+
+// ErrIndoorLandmark - лучшее время подбирается только для мест под открытым небом.
+var ErrIndoorLandmark = errors.New("best time is only available for outdoor landmarks")
+
+// indoorCategories - категории, посещение которых не зависит от погоды.
+var indoorCategories = map[string]bool{
+	"музей": true, "театр": true, "концертный зал": true, "наука": true,
+}
+
+// Веса составляющих оценки интервала, в сумме 1.
+const (
+	dryWeight     = 0.45
+	calmWeight    = 0.25
+	comfortWeight = 0.30
+)
+
+// BestTime оценивает интервалы прогноза на ближайшие пять дней по вероятности
+// осадков, ветру, комфортности температуры и светлому времени суток и возвращает
+// лучшие промежутки. В промежутки попадают только интервалы с оценкой не ниже
+// BestTimeMinScore, соседние такие интервалы объединяются. Если подходящих
+// интервалов нет, список промежутков пуст. Для мест в помещении (indoorCategories)
+// возвращается ErrIndoorLandmark.
+func (w Weather) BestTime(landmark models.Landmark, limit int) (models.BestTime, error) {
+	if indoorCategories[strings.ToLower(strings.TrimSpace(landmark.Category))] {
+		return models.BestTime{}, ErrIndoorLandmark
+	}
+	landmarkID := landmark.ID
+	if limit <= 0 {
+		limit = DefaultBestTimeLimit
+	}
+	limit = min(limit, MaxBestTimeLimit)
+	forecast, err := w.repo.GetWeatherByLandmarkID(landmarkID)
+	if err != nil {
+		return models.BestTime{}, err
+	}
+	res := models.BestTime{LandmarkID: landmarkID, Windows: make([]models.BestTimeWindow, 0)}
+	if forecast == nil {
+		return res, nil
+	}
+	horizon := time.Now().Add(BestTimeHorizon)
+	slots := make([]models.SlotScore, 0, len(*forecast))
+	for _, slot := range *forecast {
+		if slot.Date.After(horizon) {
+			continue
+		}
+		slots = append(slots, w.scoreSlot(slot))
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Date.Before(slots[j].Date) })
+
+	windows := make([]models.BestTimeWindow, 0)
+	for _, slot := range slots {
+		if slot.Score < BestTimeMinScore {
+			continue
+		}
+		if n := len(windows); n > 0 && windows[n-1].End.Equal(slot.Date) {
+			last := &windows[n-1]
+			last.Slots = append(last.Slots, slot)
+			last.End = slot.Date.Add(forecastSlotDuration)
+			continue
+		}
+		windows = append(windows, models.BestTimeWindow{
+			Start: slot.Date,
+			End:   slot.Date.Add(forecastSlotDuration),
+			Slots: []models.SlotScore{slot},
+		})
+	}
+	for i := range windows {
+		sum := 0.0
+		for _, slot := range windows[i].Slots {
+			sum += slot.Score
+		}
+		windows[i].Score = round3(sum / float64(len(windows[i].Slots)))
+	}
+	sort.SliceStable(windows, func(i, j int) bool {
+		if windows[i].Score != windows[j].Score {
+			return windows[i].Score > windows[j].Score
+		}
+		return len(windows[i].Slots) > len(windows[j].Slots)
+	})
+	if len(windows) > limit {
+		windows = windows[:limit]
+	}
+	res.Windows = windows
+	return res, nil
+}
+
+// scoreSlot оценивает один интервал прогноза.
+func (w Weather) scoreSlot(slot models.WeatherResponse) models.SlotScore {
+	temperature := slot.Temperature
+	// Ощущаемая температура есть только в прогнозах с полным набором полей.
+	if slot.Humidity > 0 {
+		temperature = slot.FeelsLike
+	}
+	s := models.SlotScore{
+		Date:        slot.Date,
+		Dry:         clamp01(1 - slot.Pop),
+		Calm:        windCalm(max(slot.WindSpeed, slot.WindGust*0.7)),
+		Comfort:     temperatureComfort(temperature),
+		Daylight:    0,
+		Temperature: slot.Temperature,
+		Pop:         slot.Pop,
+		WindSpeed:   slot.WindSpeed,
+		Description: slot.Description,
+		Icon:        slot.Icon,
+	}
+	if w.isDaylight(slot) {
+		s.Daylight = 1
+	}
+	score := dryWeight*s.Dry + calmWeight*s.Calm + comfortWeight*s.Comfort
+	if s.Daylight == 0 {
+		score *= nightFactor
+	}
+	s.Score = round3(score)
+	s.Dry, s.Calm, s.Comfort = round3(s.Dry), round3(s.Calm), round3(s.Comfort)
+	return s
+}
+
+// isDaylight определяет светлое время по признаку поставщика, а если его нет -
+// по местному времени середины интервала.
+func (w Weather) isDaylight(slot models.WeatherResponse) bool {
+	switch slot.Pod {
+	case "d":
+		return true
+	case "n":
+		return false
+	}
+	hour := slot.Date.Add(forecastSlotDuration / 2).In(w.location).Hour()
+	return hour >= 7 && hour < 20
+}
+
+// temperatureComfort равна 1 при 18–25°C и линейно падает до 0 к 8°C и 35°C.
+func temperatureComfort(t float64) float64 {
+	switch {
+	case t >= 18 && t <= 25:
+		return 1
+	case t < 18:
+		return clamp01((t - 8) / 10)
+	default:
+		return clamp01((35 - t) / 10)
+	}
+}
+
+// windCalm равна 1 при ветре до 5 м/с и линейно падает до 0 к 15 м/с.
+func windCalm(speed float64) float64 {
+	return clamp01((15 - speed) / 10)
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+func TestScoreSlot(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	w := Weather{location: loc}
+	noon := time.Date(2025, time.July, 2, 12, 0, 0, 0, loc)
+	tests := []struct {
+		name string
+		slot models.WeatherResponse
+		want float64
+	}{
+		{"ideal", models.WeatherResponse{Date: noon, Temperature: 22, WindSpeed: 2, Pod: "d"}, 1},
+		{"night", models.WeatherResponse{Date: noon, Temperature: 22, WindSpeed: 2, Pod: "n"}, 0.2},
+		{"rain", models.WeatherResponse{Date: noon, Temperature: 22, WindSpeed: 2, Pop: 1, Pod: "d"}, 0.55},
+		{"gusts", models.WeatherResponse{Date: noon, Temperature: 22, WindSpeed: 3, WindGust: 20, Pod: "d"}, 0.775},
+		{"storm", models.WeatherResponse{Date: noon, Temperature: 22, WindSpeed: 16, Pop: 1, Pod: "d"}, 0.3},
+		{"feels like", models.WeatherResponse{Date: noon, Temperature: 22, FeelsLike: 33, Humidity: 60, WindSpeed: 2, Pod: "d"}, 0.76},
+		{"cool", models.WeatherResponse{Date: noon, Temperature: 13, WindSpeed: 2, Pod: "d"}, 0.85},
+		{"freezing", models.WeatherResponse{Date: noon, Temperature: -5, WindSpeed: 2, Pod: "d"}, 0.7},
+		{"daylight by local time", models.WeatherResponse{Date: noon, Temperature: 22, WindSpeed: 2}, 1},
+		{"night by local time", models.WeatherResponse{Date: noon.Add(9 * time.Hour), Temperature: 22, WindSpeed: 2}, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.scoreSlot(tt.slot); got.Score != tt.want {
+				t.Errorf("scoreSlot() = %+v, want score %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// forecastRepo возвращает заранее заданный прогноз.
+type forecastRepo struct {
+	fakeWeatherRepo
+	forecast []models.WeatherResponse
+}
+
+func (r *forecastRepo) GetWeatherByLandmarkID(id int) (*[]models.WeatherResponse, error) {
+	return &r.forecast, nil
+}
+
+func TestBestTime(t *testing.T) {
+	start := time.Now().Truncate(time.Hour).Add(3 * time.Hour)
+	slot := func(i int, pop float64, pod string) models.WeatherResponse {
+		return models.WeatherResponse{
+			Date: start.Add(time.Duration(i) * forecastSlotDuration), Temperature: 22, WindSpeed: 2, Pop: pop, Pod: pod,
+		}
+	}
+	forecast := []models.WeatherResponse{
+		slot(3, 0, "d"),
+		slot(0, 0, "d"),
+		slot(1, 0.2, "d"),
+		slot(2, 1, "d"),
+		slot(4, 0, "n"),
+		// За горизонтом прогноза.
+		slot(48, 0, "d"),
+	}
+	window := func(from, to int, score float64) models.BestTimeWindow {
+		return models.BestTimeWindow{
+			Start: start.Add(time.Duration(from) * forecastSlotDuration),
+			End:   start.Add(time.Duration(to+1) * forecastSlotDuration),
+			Score: score,
+		}
+	}
+	tests := []struct {
+		name     string
+		landmark models.Landmark
+		forecast []models.WeatherResponse
+		limit    int
+		want     []models.BestTimeWindow
+		wantErr  error
+	}{
+		{
+			name:     "good windows only",
+			landmark: models.Landmark{ID: 1, Category: "Природа"},
+			forecast: forecast,
+			want:     []models.BestTimeWindow{window(3, 3, 1), window(0, 1, 0.955)},
+		},
+		{
+			name:     "limit",
+			landmark: models.Landmark{ID: 1, Category: "Парк"},
+			forecast: forecast,
+			limit:    1,
+			want:     []models.BestTimeWindow{window(3, 3, 1)},
+		},
+		{
+			name:     "no good slots",
+			landmark: models.Landmark{ID: 1, Category: "Природа"},
+			forecast: []models.WeatherResponse{slot(0, 1, "d"), slot(1, 0, "n")},
+			want:     []models.BestTimeWindow{},
+		},
+		{
+			name:     "indoor category",
+			landmark: models.Landmark{ID: 1, Category: " Музей "},
+			forecast: forecast,
+			wantErr:  ErrIndoorLandmark,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := Weather{repo: &forecastRepo{forecast: tt.forecast}, location: time.UTC}
+			got, err := w.BestTime(tt.landmark, tt.limit)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("BestTime() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.LandmarkID != tt.landmark.ID || got.Windows == nil || len(got.Windows) != len(tt.want) {
+				t.Fatalf("BestTime() = %+v, want windows %+v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				w := got.Windows[i]
+				if !w.Start.Equal(want.Start) || !w.End.Equal(want.End) || w.Score != want.Score {
+					t.Errorf("window %d = %s–%s score %v, want %s–%s score %v",
+						i, w.Start, w.End, w.Score, want.Start, want.End, want.Score)
+				}
+				for _, s := range w.Slots {
+					if s.Score < BestTimeMinScore {
+						t.Errorf("window %d contains slot with score %v", i, s.Score)
+					}
+				}
+			}
+		})
+	}
+}
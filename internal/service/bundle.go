@@ -0,0 +1,226 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+	"trailblazer/internal/utils"
+)
+
+var ErrUnknownRegion = errors.New("unknown region")
+
+// Bundle собирает офлайн-пакеты регионов: zip-архив с landmarks.json,
+// landmarks.geojson, уменьшенными изображениями и manifest.json.
+// Собранные пакеты кэшируются на диске в каталоге текущей версии данных.
+type Bundle struct {
+	repo     repository.Landmark
+	versions *dataVersion
+	config.BundleConfig
+
+	mu sync.Mutex
+}
+
+func NewBundleService(landmark repository.Landmark, changes repository.Sync, cfg config.BundleConfig) *Bundle {
+	if cfg.ImageQuality <= 0 {
+		cfg.ImageQuality = 75
+	}
+	return &Bundle{repo: landmark, versions: newDataVersion(changes), BundleConfig: cfg}
+}
+
+// Regions возвращает названия регионов, для которых можно собрать пакет.
+func (b *Bundle) Regions() []string {
+	regions := make([]string, 0, len(b.BundleConfig.Regions))
+	for region := range b.BundleConfig.Regions {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// GetBundle возвращает манифест и путь к архиву региона, собирая его при необходимости.
+func (b *Bundle) GetBundle(region string) (models.BundleManifest, string, error) {
+	if _, err := b.regionBBOX(region); err != nil {
+		return models.BundleManifest{}, "", err
+	}
+	version := b.versions.Current()
+	if version == "" {
+		return models.BundleManifest{}, "", errors.New("data version is unknown")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	zipPath := filepath.Join(b.Dir, version, region+".zip")
+	manifestPath := filepath.Join(b.Dir, version, region+".json")
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		var manifest models.BundleManifest
+		if err := json.Unmarshal(data, &manifest); err == nil {
+			if _, err := os.Stat(zipPath); err == nil {
+				return manifest, zipPath, nil
+			}
+		}
+	}
+
+	manifest, archive, err := b.BuildBundle(region)
+	if err != nil {
+		return models.BundleManifest{}, "", err
+	}
+	removeStaleVersions(b.Dir, version)
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return models.BundleManifest{}, "", err
+	}
+	if err := writeFileAtomic(zipPath, archive); err != nil {
+		return models.BundleManifest{}, "", fmt.Errorf("failed to save bundle: %w", err)
+	}
+	if err := writeFileAtomic(manifestPath, manifestData); err != nil {
+		return models.BundleManifest{}, "", fmt.Errorf("failed to save bundle manifest: %w", err)
+	}
+	return manifest, zipPath, nil
+}
+
+// InvalidateBundles удаляет все собранные пакеты.
+func (b *Bundle) InvalidateBundles() error {
+	if b.Dir == "" {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return os.RemoveAll(b.Dir)
+}
+
+// BuildBundle собирает пакет региона и возвращает его манифест и zip-архив.
+func (b *Bundle) BuildBundle(region string) (models.BundleManifest, []byte, error) {
+	bbox, err := b.regionBBOX(region)
+	if err != nil {
+		return models.BundleManifest{}, nil, err
+	}
+	all, err := b.repo.GetLandmarks(-1, nil)
+	if err != nil {
+		return models.BundleManifest{}, nil, err
+	}
+	images := b.imageIndex()
+
+	files := make(map[string][]byte)
+	landmarks := make([]models.Landmark, 0)
+	for _, l := range all {
+		if l.Lng < bbox.SW.Lng || l.Lng > bbox.NE.Lng || l.Lat < bbox.SW.Lat || l.Lat > bbox.NE.Lat {
+			continue
+		}
+		if source, ok := images[strings.ToLower(l.ImagePath)]; ok {
+			image, err := b.resizeImage(source)
+			if err != nil {
+				slog.Warn(fmt.Sprintf("failed to resize image %s: %v", source, err))
+				l.ImagePath = ""
+			} else {
+				l.ImagePath = "images/" + l.TranslatedName + ".jpg"
+				files[l.ImagePath] = image
+			}
+		} else {
+			l.ImagePath = ""
+		}
+		landmarks = append(landmarks, l)
+	}
+	sort.Slice(landmarks, func(i, j int) bool { return landmarks[i].ID < landmarks[j].ID })
+
+	if files["landmarks.json"], err = json.Marshal(landmarks); err != nil {
+		return models.BundleManifest{}, nil, err
+	}
+	if files["landmarks.geojson"], err = json.Marshal(models.NewFeatureCollection(landmarks)); err != nil {
+		return models.BundleManifest{}, nil, err
+	}
+
+	manifest := models.BundleManifest{Region: region, GeneratedAt: time.Now().UTC()}
+	version := sha256.New()
+	for path, data := range files {
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, models.BundleFile{Path: path, SHA256: hex.EncodeToString(sum[:]), Size: len(data)})
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].Path < manifest.Files[j].Path })
+	for _, f := range manifest.Files {
+		fmt.Fprintf(version, "%s:%s\n", f.Path, f.SHA256)
+	}
+	manifest.Version = hex.EncodeToString(version.Sum(nil))[:16]
+
+	archive, err := writeBundleArchive(manifest, files)
+	if err != nil {
+		return models.BundleManifest{}, nil, err
+	}
+	return manifest, archive, nil
+}
+
+func (b *Bundle) regionBBOX(region string) (models.BBOX, error) {
+	bounds, ok := b.BundleConfig.Regions[region]
+	if !ok || len(bounds) != 4 {
+		return models.BBOX{}, fmt.Errorf("%w: %s", ErrUnknownRegion, region)
+	}
+	return models.BBOX{
+		SW: models.Point{Lng: bounds[0], Lat: bounds[1]},
+		NE: models.Point{Lng: bounds[2], Lat: bounds[3]},
+	}, nil
+}
+
+// imageIndex сопоставляет имена файлов изображений в нижнем регистре с путями к ним.
+func (b *Bundle) imageIndex() map[string]string {
+	index := make(map[string]string)
+	entries, err := os.ReadDir(b.ImagesDir)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("failed to read images dir: %v", err))
+		return index
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			index[strings.ToLower(e.Name())] = filepath.Join(b.ImagesDir, e.Name())
+		}
+	}
+	return index
+}
+
+func (b *Bundle) resizeImage(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ResizeJPEG(data, b.ImageMaxSize, b.ImageQuality)
+}
+
+func writeBundleArchive(manifest models.BundleManifest, files map[string][]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	entries := append([]models.BundleFile{{Path: "manifest.json"}}, manifest.Files...)
+	for _, entry := range entries {
+		data := manifestData
+		if entry.Path != "manifest.json" {
+			data = files[entry.Path]
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entry.Path, Method: zip.Deflate, Modified: manifest.GeneratedAt})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+)
+
+func (r *fakeLandmarkRepo) GetLandmarks(page int, categories []string) ([]models.Landmark, error) {
+	res := make([]models.Landmark, 0, len(r.landmarks))
+	for _, l := range r.landmarks {
+		res = append(res, l)
+	}
+	return res, nil
+}
+
+func testBundleConfig(t *testing.T) config.BundleConfig {
+	t.Helper()
+	imagesDir := t.TempDir()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 400, 300))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(imagesDir, "Kreml.png"), buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write image: %v", err)
+	}
+	return config.BundleConfig{
+		ImagesDir:    imagesDir,
+		ImageMaxSize: 64,
+		Regions: map[string][]float64{
+			"moscow": {37.3, 55.5, 37.9, 56.0},
+			"broken": {37.3, 55.5},
+		},
+	}
+}
+
+func TestRegionBBOX(t *testing.T) {
+	b := NewBundleService(&fakeLandmarkRepo{}, nil, testBundleConfig(t))
+	tests := []struct {
+		region  string
+		want    models.BBOX
+		wantErr bool
+	}{
+		{region: "moscow", want: models.BBOX{SW: models.Point{Lng: 37.3, Lat: 55.5}, NE: models.Point{Lng: 37.9, Lat: 56.0}}},
+		{region: "atlantis", wantErr: true},
+		{region: "broken", wantErr: true},
+		{region: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			got, err := b.regionBBOX(tt.region)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownRegion) {
+					t.Fatalf("regionBBOX(%q) error = %v, want ErrUnknownRegion", tt.region, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("regionBBOX(%q) error = %v", tt.region, err)
+			}
+			if got.SW != tt.want.SW || got.NE != tt.want.NE {
+				t.Errorf("regionBBOX(%q) = %+v, want %+v", tt.region, got, tt.want)
+			}
+		})
+	}
+	if _, _, err := b.BuildBundle("atlantis"); !errors.Is(err, ErrUnknownRegion) {
+		t.Errorf("BuildBundle(atlantis) error = %v, want ErrUnknownRegion", err)
+	}
+}
+
+func TestBuildBundle(t *testing.T) {
+	cfg := testBundleConfig(t)
+	repo := &fakeLandmarkRepo{landmarks: map[int]models.Landmark{
+		1: {ID: 1, Name: "Кремль", TranslatedName: "kreml", ImagePath: "kreml.png", Location: models.Location{Lat: 55.75, Lng: 37.61}},
+		2: {ID: 2, Name: "Парк", TranslatedName: "park", ImagePath: "park.png", Location: models.Location{Lat: 55.70, Lng: 37.55}},
+		3: {ID: 3, Name: "Эрмитаж", TranslatedName: "ermitazh", ImagePath: "ermitazh.png", Location: models.Location{Lat: 59.94, Lng: 30.31}},
+		4: {ID: 4, Name: "Граница", Location: models.Location{Lat: 55.5, Lng: 37.9}},
+	}}
+	b := NewBundleService(repo, nil, cfg)
+
+	manifest, archive, err := b.BuildBundle("moscow")
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+	if manifest.Region != "moscow" || len(manifest.Version) != 16 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("archive is not a zip: %v", err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		files[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	if zr.File[0].Name != "manifest.json" {
+		t.Errorf("first archive entry = %s, want manifest.json", zr.File[0].Name)
+	}
+	var archived models.BundleManifest
+	if err := json.Unmarshal(files["manifest.json"], &archived); err != nil || archived.Version != manifest.Version {
+		t.Errorf("archived manifest = %+v, %v, want version %s", archived, err, manifest.Version)
+	}
+
+	version := sha256.New()
+	for i, f := range manifest.Files {
+		if i > 0 && manifest.Files[i-1].Path >= f.Path {
+			t.Errorf("manifest files are not sorted: %s before %s", manifest.Files[i-1].Path, f.Path)
+		}
+		data, ok := files[f.Path]
+		if !ok {
+			t.Errorf("archive has no %s", f.Path)
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if f.SHA256 != hex.EncodeToString(sum[:]) || f.Size != len(data) {
+			t.Errorf("manifest entry %+v does not match archived file", f)
+		}
+		fmt.Fprintf(version, "%s:%s\n", f.Path, f.SHA256)
+	}
+	if want := hex.EncodeToString(version.Sum(nil))[:16]; manifest.Version != want {
+		t.Errorf("manifest version = %s, want %s", manifest.Version, want)
+	}
+	if len(manifest.Files) != 3 {
+		t.Errorf("manifest files = %+v, want landmarks.json, landmarks.geojson and one image", manifest.Files)
+	}
+
+	var landmarks []models.Landmark
+	if err := json.Unmarshal(files["landmarks.json"], &landmarks); err != nil {
+		t.Fatalf("landmarks.json: %v", err)
+	}
+	ids := make([]int, 0, len(landmarks))
+	for _, l := range landmarks {
+		ids = append(ids, l.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 4]" {
+		t.Errorf("bundle landmarks = %v, want [1 2 4]", ids)
+	}
+	if landmarks[0].ImagePath != "images/kreml.jpg" || landmarks[1].ImagePath != "" {
+		t.Errorf("image paths = %q, %q, want images/kreml.jpg and empty", landmarks[0].ImagePath, landmarks[1].ImagePath)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(files["images/kreml.jpg"]))
+	if err != nil {
+		t.Fatalf("images/kreml.jpg is not a JPEG: %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() > cfg.ImageMaxSize || bounds.Dy() > cfg.ImageMaxSize {
+		t.Errorf("image size = %dx%d, want at most %d", bounds.Dx(), bounds.Dy(), cfg.ImageMaxSize)
+	}
+
+	again, _, err := b.BuildBundle("moscow")
+	if err != nil {
+		t.Fatalf("BuildBundle() second run error = %v", err)
+	}
+	if again.Version != manifest.Version {
+		t.Errorf("version changed between builds of the same data: %s, %s", manifest.Version, again.Version)
+	}
+}
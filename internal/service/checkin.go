@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+	"trailblazer/internal/utils"
+)
+
+// DefaultCheckInRadius используется, если checkin.radius не задан в конфигурации.
+const DefaultCheckInRadius = 200.0
+
+var (
+	ErrInvalidCheckIn = errors.New("invalid check-in")
+	ErrTooFar         = errors.New("too far from landmark")
+)
+
+type CheckIn struct {
+	repo     repository.CheckIn
+	landmark repository.Landmark
+	config.CheckInConfig
+}
+
+func NewCheckInService(checkIn repository.CheckIn, landmark repository.Landmark, cfg config.CheckInConfig) *CheckIn {
+	if cfg.Radius <= 0 {
+		cfg.Radius = DefaultCheckInRadius
+	}
+	return &CheckIn{repo: checkIn, landmark: landmark, CheckInConfig: cfg}
+}
+
+// CheckIn принимает отметку, если пользователь находится не дальше Radius метров
+// от достопримечательности.
+func (s *CheckIn) CheckIn(userID int64, req models.CheckInRequest) (models.CheckIn, error) {
+	if req.Lat < -90 || req.Lat > 90 || req.Lng < -180 || req.Lng > 180 {
+		return models.CheckIn{}, fmt.Errorf("%w: invalid coordinates", ErrInvalidCheckIn)
+	}
+	landmarks, err := s.landmark.GetLandmarksByIDs([]any{req.LandmarkID})
+	if err != nil {
+		return models.CheckIn{}, err
+	}
+	if len(landmarks) == 0 {
+		return models.CheckIn{}, fmt.Errorf("%w: landmark not found", ErrInvalidCheckIn)
+	}
+	location := models.Location{Lat: req.Lat, Lng: req.Lng}
+	distance := utils.Haversine(location, landmarks[0].Location)
+	if distance > s.Radius {
+		return models.CheckIn{}, fmt.Errorf("%w: %.0f m away, must be within %.0f m", ErrTooFar, distance, s.Radius)
+	}
+	return s.repo.AddCheckIn(models.CheckIn{
+		UserID:     userID,
+		LandmarkID: req.LandmarkID,
+		Location:   location,
+		Distance:   distance,
+	})
+}
+
+func (s *CheckIn) GetVisits(userID int64) ([]models.Visit, error) {
+	return s.repo.GetVisits(userID)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+// fakeCheckInRepo запоминает сохранённые отметки.
+type fakeCheckInRepo struct {
+	repository.CheckIn
+	added []models.CheckIn
+}
+
+func (r *fakeCheckInRepo) AddCheckIn(checkIn models.CheckIn) (models.CheckIn, error) {
+	checkIn.ID = len(r.added) + 1
+	r.added = append(r.added, checkIn)
+	return checkIn, nil
+}
+
+func TestCheckIn(t *testing.T) {
+	landmarks := &fakeLandmarkRepo{landmarks: map[int]models.Landmark{
+		1: {ID: 1, Name: "Красная площадь", Location: models.Location{Lat: 55.7539, Lng: 37.6208}},
+	}}
+	tests := []struct {
+		name    string
+		radius  float64
+		req     models.CheckInRequest
+		wantErr error
+	}{
+		{name: "at landmark", req: models.CheckInRequest{LandmarkID: 1, Lat: 55.7539, Lng: 37.6208}},
+		{name: "within default radius", req: models.CheckInRequest{LandmarkID: 1, Lat: 55.7548, Lng: 37.6208}},
+		{name: "outside default radius", req: models.CheckInRequest{LandmarkID: 1, Lat: 55.7566, Lng: 37.6208}, wantErr: ErrTooFar},
+		{name: "within configured radius", radius: 500, req: models.CheckInRequest{LandmarkID: 1, Lat: 55.7566, Lng: 37.6208}},
+		{name: "outside configured radius", radius: 50, req: models.CheckInRequest{LandmarkID: 1, Lat: 55.7548, Lng: 37.6208}, wantErr: ErrTooFar},
+		{name: "latitude out of range", req: models.CheckInRequest{LandmarkID: 1, Lat: 91, Lng: 37.6208}, wantErr: ErrInvalidCheckIn},
+		{name: "longitude out of range", req: models.CheckInRequest{LandmarkID: 1, Lat: 55.7539, Lng: -181}, wantErr: ErrInvalidCheckIn},
+		{name: "unknown landmark", req: models.CheckInRequest{LandmarkID: 42, Lat: 55.7539, Lng: 37.6208}, wantErr: ErrInvalidCheckIn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeCheckInRepo{}
+			s := NewCheckInService(repo, landmarks, config.CheckInConfig{Radius: tt.radius})
+			got, err := s.CheckIn(7, tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CheckIn() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.added) != 0 {
+					t.Errorf("rejected check-in was saved: %+v", repo.added)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckIn() error = %v", err)
+			}
+			if len(repo.added) != 1 || got.UserID != 7 || got.LandmarkID != tt.req.LandmarkID {
+				t.Fatalf("CheckIn() = %+v, saved %+v", got, repo.added)
+			}
+			if got.Location != (models.Location{Lat: tt.req.Lat, Lng: tt.req.Lng}) || got.Distance > s.Radius {
+				t.Errorf("CheckIn() location = %+v, distance = %.1f", got.Location, got.Distance)
+			}
+		})
+	}
+}
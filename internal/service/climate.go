@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+// RainyDayThreshold - осадки за сутки (мм), начиная с которых день считается дождливым.
+const RainyDayThreshold = 1.0
+
+// Rollup сводит прогнозы за прошедшие сутки в климатическую статистику по месяцам
+// и удаляет их. Текущие сутки не трогаются, чтобы каждый день попадал
+// в статистику целиком и ровно один раз.
+func (w Weather) Rollup() (models.WeatherRollupStats, error) {
+	now := time.Now().In(w.location)
+	stats := models.WeatherRollupStats{
+		Before: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, w.location),
+	}
+	purged, months, err := w.repo.RollupWeather(stats.Before, w.location.String(), RainyDayThreshold)
+	if err != nil {
+		return stats, err
+	}
+	stats.Purged, stats.Months = purged, months
+	slog.Info(fmt.Sprintf("weather rollup: %d forecast rows before %s merged into %d climate months",
+		purged, stats.Before.Format(time.DateOnly), months))
+	return stats, nil
+}
+
+func (w Weather) GetClimate(landmarkID int) (models.Climate, error) {
+	months, err := w.repo.GetClimate(landmarkID)
+	if err != nil {
+		return models.Climate{}, err
+	}
+	return models.Climate{LandmarkID: landmarkID, Months: months}, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+// rollupRepo запоминает аргументы RollupWeather.
+type rollupRepo struct {
+	fakeWeatherRepo
+	before    time.Time
+	timezone  string
+	threshold float64
+	err       error
+}
+
+func (r *rollupRepo) RollupWeather(before time.Time, timezone string, rainyThreshold float64) (int, int, error) {
+	r.before, r.timezone, r.threshold = before, timezone, rainyThreshold
+	return 12, 2, r.err
+}
+
+func TestWeatherRollup(t *testing.T) {
+	tests := []struct {
+		name     string
+		location *time.Location
+		err      error
+	}{
+		{name: "utc+3", location: time.FixedZone("Etc/GMT-3", 3*60*60)},
+		{name: "utc", location: time.UTC},
+		{name: "repository error", location: time.UTC, err: errors.New("db is down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &rollupRepo{err: tt.err}
+			w := Weather{repo: repo, location: tt.location}
+			now := time.Now().In(tt.location)
+			stats, err := w.Rollup()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Rollup() error = %v, want %v", err, tt.err)
+			}
+
+			// Срез - местная полночь текущих суток.
+			if repo.before.Location() != tt.location || repo.before.Hour() != 0 || repo.before.Minute() != 0 ||
+				repo.before.Day() != now.Day() || repo.before.After(now) || now.Sub(repo.before) >= 24*time.Hour {
+				t.Errorf("cutoff = %s, want local midnight of %s", repo.before, now.Format(time.DateOnly))
+			}
+			if repo.timezone != tt.location.String() || repo.threshold != RainyDayThreshold {
+				t.Errorf("RollupWeather() called with %q, %v", repo.timezone, repo.threshold)
+			}
+			want := models.WeatherRollupStats{Before: repo.before, Purged: 12, Months: 2}
+			if tt.err != nil {
+				want.Purged, want.Months = 0, 0
+			}
+			if stats != want {
+				t.Errorf("Rollup() = %+v, want %+v", stats, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"trailblazer/internal/models"
+)
+
+const (
+	// MaxClusterZoom - наибольший масштаб, при котором точки ещё объединяются в кластеры.
+	MaxClusterZoom = 11
+	// clusterCellSize - размер ячейки сетки в пикселях тайла 256×256.
+	clusterCellSize = 64
+	// topCategoriesCount - сколько самых частых категорий возвращать для кластера.
+	topCategoriesCount = 3
+)
+
+// ShouldCluster сообщает, нужно ли объединять точки в кластеры на данном масштабе.
+// Если масштаб не передан, кластеры не строятся.
+func ShouldCluster(zoom *int) bool {
+	return zoom != nil && *zoom >= 0 && *zoom <= MaxClusterZoom
+}
+
+func (s *Landmark) GetFacilityClusters(bbox models.BBOX) (models.FacilityClusters, error) {
+	facilities, err := s.GetFacilities(bbox)
+	if err != nil {
+		return models.FacilityClusters{}, err
+	}
+	zoom := 0
+	if bbox.Zoom != nil {
+		zoom = *bbox.Zoom
+	}
+	return clusterLandmarks(facilities, zoom), nil
+}
+
+// clusterLandmarks группирует точки по ячейкам сетки в проекции Web Mercator.
+// Ячейки с единственной точкой возвращаются как обычные достопримечательности.
+func clusterLandmarks(landmarks []models.Landmark, zoom int) models.FacilityClusters {
+	type cell struct{ x, y int }
+	cells := make(map[cell][]models.Landmark)
+	order := make([]cell, 0)
+	for _, l := range landmarks {
+		x, y := mercatorPixel(l.Location, zoom)
+		c := cell{x: int(x / clusterCellSize), y: int(y / clusterCellSize)}
+		if _, ok := cells[c]; !ok {
+			order = append(order, c)
+		}
+		cells[c] = append(cells[c], l)
+	}
+
+	res := models.FacilityClusters{
+		Zoom:      zoom,
+		Clusters:  make([]models.Cluster, 0),
+		Landmarks: make([]models.Landmark, 0),
+	}
+	for _, c := range order {
+		members := cells[c]
+		if len(members) == 1 {
+			res.Landmarks = append(res.Landmarks, members[0])
+			continue
+		}
+		res.Clusters = append(res.Clusters, newCluster(members))
+	}
+	return res
+}
+
+func newCluster(members []models.Landmark) models.Cluster {
+	cluster := models.Cluster{Count: len(members), IDs: make([]int, 0, len(members))}
+	categories := make(map[string]int)
+	for _, l := range members {
+		cluster.Lat += l.Lat
+		cluster.Lng += l.Lng
+		cluster.IDs = append(cluster.IDs, l.ID)
+		if l.Category != "" {
+			categories[l.Category]++
+		}
+	}
+	cluster.Lat /= float64(len(members))
+	cluster.Lng /= float64(len(members))
+
+	cluster.TopCategories = make([]models.CategoryCount, 0, len(categories))
+	for category, count := range categories {
+		cluster.TopCategories = append(cluster.TopCategories, models.CategoryCount{Category: category, Count: count})
+	}
+	sort.Slice(cluster.TopCategories, func(i, j int) bool {
+		a, b := cluster.TopCategories[i], cluster.TopCategories[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Category < b.Category
+	})
+	if len(cluster.TopCategories) > topCategoriesCount {
+		cluster.TopCategories = cluster.TopCategories[:topCategoriesCount]
+	}
+	return cluster
+}
+
+// mercatorPixel возвращает пиксельные координаты точки на карте мира заданного масштаба.
+func mercatorPixel(loc models.Location, zoom int) (float64, float64) {
+	size := 256 * math.Exp2(float64(zoom))
+	lat := math.Max(-85.05112878, math.Min(85.05112878, loc.Lat)) * math.Pi / 180
+	x := (loc.Lng + 180) / 360 * size
+	y := (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * size
+	return x, y
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"trailblazer/internal/models"
+)
+
+func TestShouldCluster(t *testing.T) {
+	zoom := func(z int) *int { return &z }
+	tests := []struct {
+		name string
+		zoom *int
+		want bool
+	}{
+		{"no zoom", nil, false},
+		{"world", zoom(0), true},
+		{"region", zoom(8), true},
+		{"max cluster zoom", zoom(MaxClusterZoom), true},
+		{"street", zoom(MaxClusterZoom + 1), false},
+		{"negative", zoom(-1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShouldCluster(tt.zoom); got != tt.want {
+				t.Errorf("ShouldCluster() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMercatorPixel(t *testing.T) {
+	tests := []struct {
+		name  string
+		loc   models.Location
+		zoom  int
+		wantX float64
+		wantY float64
+	}{
+		{"origin", models.Location{}, 0, 128, 128},
+		{"origin zoom 2", models.Location{}, 2, 512, 512},
+		{"west antimeridian", models.Location{Lng: -180}, 0, 0, 128},
+		{"east antimeridian", models.Location{Lng: 180}, 0, 256, 128},
+		{"mercator limit", models.Location{Lat: 85.05112878}, 0, 128, 0},
+		{"north pole clamped", models.Location{Lat: 90}, 0, 128, 0},
+		{"south pole clamped", models.Location{Lat: -90}, 0, 128, 256},
+		{"moscow", models.Location{Lat: 55.7558, Lng: 37.6173}, 10, 158464.1, 81946.9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := mercatorPixel(tt.loc, tt.zoom)
+			if math.Abs(x-tt.wantX) > 0.1 || math.Abs(y-tt.wantY) > 0.1 {
+				t.Errorf("mercatorPixel(%+v, %d) = (%.1f, %.1f), want (%.1f, %.1f)",
+					tt.loc, tt.zoom, x, y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestClusterLandmarks(t *testing.T) {
+	landmark := func(id int, lat, lng float64, category string) models.Landmark {
+		return models.Landmark{ID: id, Category: category, Location: models.Location{Lat: lat, Lng: lng}}
+	}
+	tests := []struct {
+		name         string
+		landmarks    []models.Landmark
+		zoom         int
+		wantClusters []models.Cluster
+		wantSingles  []int
+	}{
+		{
+			name:        "far apart points stay single",
+			landmarks:   []models.Landmark{landmark(1, 55.75, 37.61, "Музей"), landmark(2, 59.93, 30.31, "Музей")},
+			zoom:        8,
+			wantSingles: []int{1, 2},
+		},
+		{
+			name: "close points form cluster",
+			landmarks: []models.Landmark{
+				landmark(1, 55.750, 37.610, "Музей"),
+				landmark(2, 55.752, 37.614, "Парк"),
+				landmark(3, 55.754, 37.612, "Музей"),
+				landmark(4, 59.930, 30.310, "Театр"),
+			},
+			zoom: 8,
+			wantClusters: []models.Cluster{{
+				Count:         3,
+				Location:      models.Location{Lat: 55.752, Lng: 37.612},
+				IDs:           []int{1, 2, 3},
+				TopCategories: []models.CategoryCount{{Category: "Музей", Count: 2}, {Category: "Парк", Count: 1}},
+			}},
+			wantSingles: []int{4},
+		},
+		{
+			name: "top categories by count then name",
+			landmarks: []models.Landmark{
+				landmark(1, 55.750, 37.610, "Театр"),
+				landmark(2, 55.750, 37.610, "Парк"),
+				landmark(3, 55.750, 37.610, "Музей"),
+				landmark(4, 55.750, 37.610, "Храм"),
+				landmark(5, 55.750, 37.610, "Храм"),
+				landmark(6, 55.750, 37.610, ""),
+			},
+			zoom: 5,
+			wantClusters: []models.Cluster{{
+				Count:    6,
+				Location: models.Location{Lat: 55.75, Lng: 37.61},
+				IDs:      []int{1, 2, 3, 4, 5, 6},
+				TopCategories: []models.CategoryCount{
+					{Category: "Храм", Count: 2}, {Category: "Музей", Count: 1}, {Category: "Парк", Count: 1},
+				},
+			}},
+		},
+		{
+			name:        "antimeridian sides are not merged",
+			landmarks:   []models.Landmark{landmark(1, 65, 179.99, ""), landmark(2, 65, -179.99, "")},
+			zoom:        0,
+			wantSingles: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clusterLandmarks(tt.landmarks, tt.zoom)
+			if got.Zoom != tt.zoom {
+				t.Errorf("Zoom = %d, want %d", got.Zoom, tt.zoom)
+			}
+			singles := make([]int, 0, len(got.Landmarks))
+			for _, l := range got.Landmarks {
+				singles = append(singles, l.ID)
+			}
+			if fmt.Sprint(singles) != fmt.Sprint(tt.wantSingles) {
+				t.Errorf("singles = %v, want %v", singles, tt.wantSingles)
+			}
+			if len(got.Clusters) != len(tt.wantClusters) {
+				t.Fatalf("clusters = %+v, want %+v", got.Clusters, tt.wantClusters)
+			}
+			for i, want := range tt.wantClusters {
+				c := got.Clusters[i]
+				if c.Count != want.Count || fmt.Sprint(c.IDs) != fmt.Sprint(want.IDs) ||
+					fmt.Sprint(c.TopCategories) != fmt.Sprint(want.TopCategories) {
+					t.Errorf("cluster %d = %+v, want %+v", i, c, want)
+				}
+				if math.Abs(c.Lat-want.Lat) > 1e-9 || math.Abs(c.Lng-want.Lng) > 1e-9 {
+					t.Errorf("cluster %d centroid = %+v, want %+v", i, c.Location, want.Location)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+// DefaultWeatherTimezone - часовой пояс по умолчанию для сводок по дням.
+const DefaultWeatherTimezone = "Europe/Simferopol"
+
+// loadWeatherLocation возвращает часовой пояс name. Если база часовых поясов
+// недоступна, используется фиксированное смещение UTC+3.
+func loadWeatherLocation(name string) *time.Location {
+	if name == "" {
+		name = DefaultWeatherTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("failed to load timezone %s, using UTC+3: %v", name, err))
+		return time.FixedZone("Etc/GMT-3", 3*60*60)
+	}
+	return loc
+}
+
+// DailySummary сводит 3-часовые интервалы прогноза в сутки по местному времени.
+// Преобладающие условия выбираются по дневным интервалам, а при их отсутствии -
+// по всем; при равенстве побеждает более сильное явление (больший код иконки).
+func (w Weather) DailySummary(forecast []models.WeatherResponse) []models.DailyWeather {
+	type condition struct{ description, icon string }
+	type day struct {
+		summary models.DailyWeather
+		all     map[condition]int
+		daytime map[condition]int
+	}
+	days := make(map[string]*day)
+	order := make([]string, 0)
+	for _, slot := range forecast {
+		date := slot.Date.In(w.location).Format(time.DateOnly)
+		d, ok := days[date]
+		if !ok {
+			d = &day{
+				summary: models.DailyWeather{
+					LandmarkID: slot.LandmarkID,
+					Date:       date,
+					TempMin:    slot.Temperature,
+					TempMax:    slot.Temperature,
+				},
+				all:     make(map[condition]int),
+				daytime: make(map[condition]int),
+			}
+			days[date] = d
+			order = append(order, date)
+		}
+		s := &d.summary
+		s.TempMin = min(s.TempMin, slot.Temperature)
+		s.TempMax = max(s.TempMax, slot.Temperature)
+		s.Rain += slot.Rain
+		s.WindSpeedMax = max(s.WindSpeedMax, slot.WindSpeed)
+		s.PopMax = max(s.PopMax, slot.Pop)
+		s.Slots++
+		c := condition{description: slot.Description, icon: strings.TrimRight(slot.Icon, "dn")}
+		d.all[c]++
+		if slot.Pod == "d" {
+			d.daytime[c]++
+		}
+	}
+	sort.Strings(order)
+
+	res := make([]models.DailyWeather, 0, len(order))
+	for _, date := range order {
+		d := days[date]
+		counts := d.daytime
+		if len(counts) == 0 {
+			counts = d.all
+		}
+		var best condition
+		bestCount := -1
+		for c, n := range counts {
+			if n > bestCount || (n == bestCount && (c.icon > best.icon || (c.icon == best.icon && c.description < best.description))) {
+				best, bestCount = c, n
+			}
+		}
+		d.summary.Description = best.description
+		if best.icon != "" {
+			d.summary.Icon = best.icon + "d"
+		}
+		res = append(res, d.summary)
+	}
+	return res
+}
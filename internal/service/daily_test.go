@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+func TestDailySummary(t *testing.T) {
+	w := Weather{location: time.FixedZone("UTC+3", 3*60*60)}
+	slot := func(utc string, temp, rain float64, description, icon, pod string) models.WeatherResponse {
+		date, err := time.Parse(time.DateTime, utc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return models.WeatherResponse{
+			LandmarkID: 7, Date: date, Temperature: temp, Rain: rain, WindSpeed: temp / 4, Pop: rain / 10,
+			Description: description, Icon: icon, Pod: pod,
+		}
+	}
+	tests := []struct {
+		name     string
+		forecast []models.WeatherResponse
+		want     []models.DailyWeather
+	}{
+		{
+			name:     "empty",
+			forecast: nil,
+			want:     []models.DailyWeather{},
+		},
+		{
+			name: "local midnight splits days",
+			forecast: []models.WeatherResponse{
+				slot("2025-07-01 18:00:00", 20, 0, "ясно", "01n", "n"),
+				slot("2025-07-01 21:00:00", 18, 0.5, "небольшой дождь", "10n", "n"),
+				slot("2025-07-02 00:00:00", 17, 1, "небольшой дождь", "10n", "n"),
+			},
+			want: []models.DailyWeather{
+				{LandmarkID: 7, Date: "2025-07-01", TempMin: 20, TempMax: 20, Description: "ясно", Icon: "01d", WindSpeedMax: 5, Slots: 1},
+				{LandmarkID: 7, Date: "2025-07-02", TempMin: 17, TempMax: 18, Rain: 1.5, Description: "небольшой дождь", Icon: "10d", WindSpeedMax: 4.5, PopMax: 0.1, Slots: 2},
+			},
+		},
+		{
+			name: "daytime conditions win over night",
+			forecast: []models.WeatherResponse{
+				slot("2025-07-02 00:00:00", 16, 2, "дождь", "10n", "n"),
+				slot("2025-07-02 03:00:00", 15, 2, "дождь", "10n", "n"),
+				slot("2025-07-02 06:00:00", 22, 0, "облачно", "03d", "d"),
+				slot("2025-07-02 09:00:00", 26, 0, "облачно", "03d", "d"),
+				slot("2025-07-02 12:00:00", 24, 0, "ясно", "01d", "d"),
+			},
+			want: []models.DailyWeather{
+				{LandmarkID: 7, Date: "2025-07-02", TempMin: 15, TempMax: 26, Rain: 4, Description: "облачно", Icon: "03d", WindSpeedMax: 6.5, PopMax: 0.2, Slots: 5},
+			},
+		},
+		{
+			name: "tie goes to stronger condition",
+			forecast: []models.WeatherResponse{
+				slot("2025-07-02 06:00:00", 20, 0, "ясно", "01d", "d"),
+				slot("2025-07-02 09:00:00", 20, 3, "гроза", "11d", "d"),
+			},
+			want: []models.DailyWeather{
+				{LandmarkID: 7, Date: "2025-07-02", TempMin: 20, TempMax: 20, Rain: 3, Description: "гроза", Icon: "11d", WindSpeedMax: 5, PopMax: 0.3, Slots: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := w.DailySummary(tt.forecast)
+			if len(got) != len(tt.want) {
+				t.Fatalf("DailySummary() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("day %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+// JobHistorySize - сколько последних запусков хранится в состоянии задачи.
+const JobHistorySize = 10
+
+// Имена фоновых задач.
+const (
+	JobWeather          = "weather"
+	JobWeatherRetention = "weather-retention"
+)
+
+var (
+	ErrJobRunning = errors.New("job is already running")
+	ErrUnknownJob = errors.New("unknown job")
+)
+
+// JobTask выполняет работу задачи и заполняет счётчики и детали запуска.
+// ctx отменяется при остановке сервера.
+type JobTask func(ctx context.Context, run *models.JobRun) error
+
+// Job - фоновая задача, выполняемая по расписанию или вручную.
+// Одновременно выполняется не больше одного запуска задачи.
+type Job struct {
+	name     string
+	interval time.Duration
+	task     JobTask
+
+	mu sync.Mutex
+	// ctx - контекст расписания, в нём же выполняются ручные запуски.
+	ctx     context.Context
+	running bool
+	nextRun time.Time
+	history []models.JobRun
+}
+
+func NewJob(name string, interval time.Duration, task JobTask) *Job {
+	return &Job{name: name, interval: interval, task: task, ctx: context.Background()}
+}
+
+// NewWeatherJob создаёт задачу обновления прогнозов. Список достопримечательностей
+// загружается заново при каждом запуске, поэтому новые точки получают прогноз
+// в ближайшем цикле.
+func NewWeatherJob(weather WeatherService, landmark repository.Landmark, interval time.Duration) *Job {
+	return NewJob(JobWeather, interval, func(ctx context.Context, run *models.JobRun) error {
+		landmarks, err := landmark.GetLandmarks(-1, nil)
+		if err != nil {
+			return err
+		}
+		stats, err := weather.Refresh(ctx, landmarks)
+		run.Succeeded = stats.Updated
+		run.Failed = stats.Landmarks - stats.Updated
+		run.Details = stats
+		return err
+	})
+}
+
+// NewWeatherRetentionJob создаёт задачу, которая сводит прошедшие прогнозы
+// в климатическую статистику и удаляет их.
+func NewWeatherRetentionJob(weather WeatherService, interval time.Duration) *Job {
+	return NewJob(JobWeatherRetention, interval, func(ctx context.Context, run *models.JobRun) error {
+		stats, err := weather.Rollup()
+		run.Succeeded = stats.Purged
+		run.Details = stats
+		return err
+	})
+}
+
+// Start запускает задачу сразу и затем каждые interval до отмены ctx.
+// При нулевом интервале расписание отключено, доступен только ручной запуск.
+func (j *Job) Start(ctx context.Context) {
+	j.mu.Lock()
+	j.ctx = ctx
+	j.mu.Unlock()
+	if j.interval <= 0 {
+		slog.Info(fmt.Sprintf("%s job schedule is disabled", j.name))
+		return
+	}
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		j.mu.Lock()
+		j.nextRun = time.Now().Add(j.interval)
+		j.mu.Unlock()
+		if _, ok := j.begin(); ok {
+			j.run(ctx, models.JobTriggerSchedule)
+		} else {
+			slog.Warn(fmt.Sprintf("scheduled %s job skipped: %v", j.name, ErrJobRunning))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Trigger запускает задачу вне расписания, не дожидаясь её завершения.
+// Задача считается выполняющейся сразу после возврата из Trigger.
+func (j *Job) Trigger() error {
+	ctx, ok := j.begin()
+	if !ok {
+		return ErrJobRunning
+	}
+	go j.run(ctx, models.JobTriggerManual)
+	return nil
+}
+
+// begin отмечает задачу выполняющейся и возвращает контекст запуска.
+// Если задача уже выполняется, возвращает false.
+func (j *Job) begin() (context.Context, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.running {
+		return nil, false
+	}
+	j.running = true
+	return j.ctx, true
+}
+
+func (j *Job) Status() models.JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	status := models.JobStatus{
+		Name:     j.name,
+		Enabled:  j.interval > 0,
+		Interval: j.interval.String(),
+		Running:  j.running,
+		History:  make([]models.JobRun, len(j.history)),
+	}
+	if status.Enabled && !j.nextRun.IsZero() {
+		next := j.nextRun
+		status.NextRunAt = &next
+	}
+	for i, run := range j.history {
+		status.History[len(j.history)-1-i] = run
+	}
+	if len(status.History) > 0 {
+		last := status.History[0]
+		status.LastRun = &last
+	}
+	return status
+}
+
+// run выполняет задачу, начатую вызовом begin, и записывает результат в историю.
+func (j *Job) run(ctx context.Context, trigger string) {
+	run := models.JobRun{Trigger: trigger, StartedAt: time.Now()}
+	if err := j.task(ctx, &run); err != nil {
+		run.Error = err.Error()
+		slog.Error(fmt.Sprintf("%s job failed: %v", j.name, err))
+	}
+	run.FinishedAt = time.Now()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt).Seconds()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.running = false
+	j.history = append(j.history, run)
+	if len(j.history) > JobHistorySize {
+		j.history = j.history[len(j.history)-JobHistorySize:]
+	}
+}
+
+// Scheduler хранит фоновые задачи сервера по именам.
+type Scheduler struct {
+	jobs map[string]*Job
+}
+
+func NewScheduler(jobs ...*Job) *Scheduler {
+	s := &Scheduler{jobs: make(map[string]*Job, len(jobs))}
+	for _, job := range jobs {
+		s.jobs[job.name] = job
+	}
+	return s
+}
+
+// StartJobs запускает расписание всех задач и сразу возвращает управление.
+func (s *Scheduler) StartJobs(ctx context.Context) {
+	for _, job := range s.jobs {
+		go job.Start(ctx)
+	}
+}
+
+func (s *Scheduler) Jobs() []models.JobStatus {
+	statuses := make([]models.JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		statuses = append(statuses, job.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+func (s *Scheduler) JobStatus(name string) (models.JobStatus, error) {
+	job, ok := s.jobs[name]
+	if !ok {
+		return models.JobStatus{}, fmt.Errorf("%w: %s", ErrUnknownJob, name)
+	}
+	return job.Status(), nil
+}
+
+func (s *Scheduler) TriggerJob(name string) error {
+	job, ok := s.jobs[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownJob, name)
+	}
+	return job.Trigger()
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+func TestJobTrigger(t *testing.T) {
+	release := make(chan struct{})
+	job := NewJob("test", 0, func(ctx context.Context, run *models.JobRun) error {
+		<-release
+		run.Succeeded = 1
+		return nil
+	})
+
+	if err := job.Trigger(); err != nil {
+		t.Fatalf("first Trigger() error = %v", err)
+	}
+	if !job.Status().Running {
+		t.Errorf("Status().Running = false right after Trigger()")
+	}
+	if err := job.Trigger(); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("second Trigger() error = %v, want ErrJobRunning", err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for job.Status().Running && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	status := job.Status()
+	if status.Running {
+		t.Fatalf("job is still running")
+	}
+	if len(status.History) != 1 || status.LastRun.Trigger != models.JobTriggerManual || status.LastRun.Succeeded != 1 {
+		t.Errorf("Status() = %+v, want one manual run", status)
+	}
+	if err := job.Trigger(); err != nil {
+		t.Errorf("Trigger() after finish error = %v", err)
+	}
+}
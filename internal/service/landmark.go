@@ -1,6 +1,9 @@
 package service
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"trailblazer/internal/config"
@@ -11,17 +14,28 @@ import (
 type Landmark struct {
 	repo repository.Landmark
 	config.ParserConfig
-	cookies []*http.Cookie
+	cookies  []*http.Cookie
+	cache    *searchCache
+	searcher Searcher
+	tiles    TileService
+	bundles  BundleService
 }
 
-func NewLandmarkService(landmark repository.Landmark, cfg config.ParserConfig) *Landmark {
+func NewLandmarkService(landmark repository.Landmark, cfg config.ParserConfig, cache *searchCache, searcher Searcher, tiles TileService, bundles BundleService) *Landmark {
 	return &Landmark{
 		repo:         landmark,
 		ParserConfig: cfg,
+		cache:        cache,
+		searcher:     searcher,
+		tiles:        tiles,
+		bundles:      bundles,
 	}
 }
 
 func (s *Landmark) GetFacilities(bbox models.BBOX) ([]models.Landmark, error) {
+	if err := normalizeArea(&bbox); err != nil {
+		return nil, err
+	}
 	return s.repo.GetFacilities(bbox)
 
 }
@@ -38,12 +52,37 @@ func (s *Landmark) GetLandmarksByIDs(ids []int) ([]models.Landmark, error) {
 	return s.repo.GetLandmarksByIDs(ID)
 
 }
-func (s *Landmark) Search(q string) ([]models.Landmark, error) {
-	return s.repo.Search(q)
+func (s *Landmark) Search(q string, origin *models.Location) ([]models.Landmark, error) {
+	phrases := s.cache.Expand(q)
+	key := searchCacheKey(phrases, origin)
+	if landmarks, ok := s.cache.Get(key); ok {
+		return landmarks, nil
+	}
+	landmarks, err := s.searcher.Search(phrases, origin)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(key, landmarks)
+	return landmarks, nil
 }
 
 func (s *Landmark) UpdateImagePath(place, path string) error {
-	return s.repo.UpdateImagePath(place, path)
+	if err := s.repo.UpdateImagePath(place, path); err != nil {
+		return err
+	}
+	return s.landmarksChanged()
+}
+
+// landmarksChanged сбрасывает всё, что построено по данным о достопримечательностях.
+func (s *Landmark) landmarksChanged() error {
+	s.cache.Invalidate()
+	if err := s.tiles.InvalidateTiles(); err != nil {
+		return err
+	}
+	if err := s.bundles.InvalidateBundles(); err != nil {
+		return err
+	}
+	return s.searcher.Refresh()
 }
 func (s *Landmark) GetLandmarksByName(name string) (models.Landmark, error) {
 	return s.repo.GetLandmarksByName(name)
@@ -52,3 +91,94 @@ func (s *Landmark) GetLandmarksByName(name string) (models.Landmark, error) {
 func (s *Landmark) GetLandmarksByCategories(categories []string) ([]models.Landmark, error) {
 	return s.repo.GetLandmarksByCategories(categories)
 }
+
+// Ограничения поиска поблизости.
+const (
+	DefaultNearbyRadius = 5000.0
+	MaxNearbyRadius     = 50000.0
+	DefaultNearbyLimit  = 20
+	MaxNearbyLimit      = 100
+)
+
+func (s *Landmark) GetNearby(q models.NearbyQuery) ([]models.Landmark, error) {
+	if q.Radius <= 0 {
+		q.Radius = DefaultNearbyRadius
+	}
+	q.Radius = min(q.Radius, MaxNearbyRadius)
+	if q.Limit <= 0 {
+		q.Limit = DefaultNearbyLimit
+	}
+	q.Limit = min(q.Limit, MaxNearbyLimit)
+	return s.repo.GetNearby(q)
+}
+
+// MaxAreaPositions - наибольшее число вершин в области поиска.
+const MaxAreaPositions = 10000
+
+var ErrInvalidArea = errors.New("invalid area")
+
+// normalizeArea проверяет область поиска объектов. Кольца полигонов, которые
+// не замкнуты, замыкаются; радиус круга ограничивается MaxNearbyRadius.
+func normalizeArea(bbox *models.BBOX) error {
+	if bbox.Area != nil {
+		raw, err := json.Marshal(bbox.Area.Coordinates)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArea, err)
+		}
+		var polygons [][][][]float64
+		switch bbox.Area.Type {
+		case "Polygon":
+			var polygon [][][]float64
+			if err := json.Unmarshal(raw, &polygon); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidArea, err)
+			}
+			polygons = [][][][]float64{polygon}
+		case "MultiPolygon":
+			if err := json.Unmarshal(raw, &polygons); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidArea, err)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported geometry type %q", ErrInvalidArea, bbox.Area.Type)
+		}
+		if len(polygons) == 0 {
+			return fmt.Errorf("%w: empty geometry", ErrInvalidArea)
+		}
+		positions := 0
+		for _, polygon := range polygons {
+			if len(polygon) == 0 {
+				return fmt.Errorf("%w: polygon without rings", ErrInvalidArea)
+			}
+			for i, ring := range polygon {
+				for _, p := range ring {
+					if len(p) < 2 || p[0] < -180 || p[0] > 180 || p[1] < -90 || p[1] > 90 {
+						return fmt.Errorf("%w: invalid position %v", ErrInvalidArea, p)
+					}
+				}
+				if n := len(ring); n > 0 && (ring[0][0] != ring[n-1][0] || ring[0][1] != ring[n-1][1]) {
+					ring = append(ring, ring[0])
+					polygon[i] = ring
+				}
+				if len(ring) < 4 {
+					return fmt.Errorf("%w: ring must have at least 3 distinct positions", ErrInvalidArea)
+				}
+				positions += len(ring)
+			}
+		}
+		if positions > MaxAreaPositions {
+			return fmt.Errorf("%w: too many positions (max %d)", ErrInvalidArea, MaxAreaPositions)
+		}
+		if bbox.Area.Type == "Polygon" {
+			bbox.Area = &models.Geometry{Type: "Polygon", Coordinates: polygons[0]}
+		} else {
+			bbox.Area = &models.Geometry{Type: "MultiPolygon", Coordinates: polygons}
+		}
+		return nil
+	}
+	if bbox.Center != nil {
+		if bbox.Radius <= 0 {
+			return fmt.Errorf("%w: radius must be positive", ErrInvalidArea)
+		}
+		bbox.Radius = min(bbox.Radius, MaxNearbyRadius)
+	}
+	return nil
+}
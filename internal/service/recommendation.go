@@ -0,0 +1,236 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+// Параметры подбора рекомендаций на сегодня.
+const (
+	RecommendationRadius     = 30000.0
+	RecommendationCandidates = 100
+	DefaultRecommendations   = 10
+	MaxRecommendations       = 50
+
+	// recommendationDistanceScale - расстояние (м), на котором вклад близости падает вдвое.
+	recommendationDistanceScale = 5000.0
+	// recommendationWeatherWeight - доля соответствия погоде в итоговой оценке.
+	recommendationWeatherWeight = 0.7
+)
+
+// categorySuitability - насколько категория подходит для погодных условий (0–1).
+// Категории сравниваются в нижнем регистре без пробелов по краям.
+var categorySuitability = map[string]map[string]float64{
+	models.ConditionRain: {
+		"музей": 1, "театр": 1, "концертный зал": 1, "наука": 1, "религия": 0.7,
+		"арт-объект": 0.3, "архитектура": 0.3, "необычное": 0.3, "памятник": 0.2,
+		"археология": 0.2, "фонтан": 0.1, "парк": 0.1, "природа": 0.05,
+	},
+	models.ConditionClear: {
+		"природа": 1, "парк": 1, "фонтан": 0.9, "археология": 0.85, "архитектура": 0.8,
+		"памятник": 0.8, "арт-объект": 0.8, "необычное": 0.8, "религия": 0.6,
+		"музей": 0.4, "наука": 0.4, "театр": 0.3, "концертный зал": 0.3,
+	},
+	models.ConditionCloudy: {
+		"архитектура": 0.8, "памятник": 0.8, "археология": 0.8, "необычное": 0.8,
+		"арт-объект": 0.75, "парк": 0.75, "религия": 0.7, "музей": 0.7, "наука": 0.7,
+		"природа": 0.65, "фонтан": 0.6, "театр": 0.6, "концертный зал": 0.6,
+	},
+	models.ConditionHot: {
+		"музей": 0.95, "наука": 0.95, "театр": 0.85, "концертный зал": 0.85, "религия": 0.8,
+		"фонтан": 0.8, "парк": 0.75, "природа": 0.5, "необычное": 0.5, "арт-объект": 0.45,
+		"архитектура": 0.4, "памятник": 0.35, "археология": 0.3,
+	},
+	models.ConditionCold: {
+		"музей": 1, "театр": 1, "концертный зал": 1, "наука": 1, "религия": 0.8,
+		"архитектура": 0.4, "необычное": 0.4, "арт-объект": 0.35, "памятник": 0.3,
+		"археология": 0.3, "парк": 0.3, "фонтан": 0.2, "природа": 0.2,
+	},
+	models.ConditionWindy: {
+		"музей": 0.95, "театр": 0.95, "концертный зал": 0.95, "наука": 0.95, "религия": 0.8,
+		"архитектура": 0.5, "необычное": 0.5, "арт-объект": 0.45, "памятник": 0.45,
+		"парк": 0.45, "археология": 0.4, "фонтан": 0.35, "природа": 0.25,
+	},
+}
+
+// conditionPhrases - начало пояснения для погодных условий.
+var conditionPhrases = map[string]string{
+	models.ConditionRain:   "Сегодня ожидаются осадки (вероятность до %.0f%%)",
+	models.ConditionClear:  "Сегодня ясно, до %.0f°C",
+	models.ConditionCloudy: "Сегодня облачно, до %.0f°C",
+	models.ConditionHot:    "Сегодня жарко, до %.0f°C",
+	models.ConditionCold:   "Сегодня холодно, не выше %.0f°C",
+	models.ConditionWindy:  "Сегодня сильный ветер, до %.0f м/с",
+}
+
+// fitPhrases - пояснение, почему место подходит под погоду.
+var fitPhrases = map[string]string{
+	models.ConditionRain:   "подойдёт место под крышей",
+	models.ConditionClear:  "отличная погода для мест на открытом воздухе",
+	models.ConditionCloudy: "погода подходит для неспешной прогулки",
+	models.ConditionHot:    "здесь можно переждать жару",
+	models.ConditionCold:   "здесь можно согреться",
+	models.ConditionWindy:  "здесь не страшен ветер",
+}
+
+// dayWeather - сводка прогноза на оставшуюся часть дня.
+type dayWeather struct {
+	condition string
+	tempMax   float64
+	tempMin   float64
+	popMax    float64
+	rain      float64
+	windMax   float64
+}
+
+type Recommendation struct {
+	landmark repository.Landmark
+	weather  WeatherService
+	location *time.Location
+}
+
+func NewRecommendationService(landmark repository.Landmark, weather WeatherService, timezone string) *Recommendation {
+	return &Recommendation{landmark: landmark, weather: weather, location: loadWeatherLocation(timezone)}
+}
+
+// Today подбирает места рядом с origin по прогнозу на остаток дня, соответствию
+// категории погоде и расстоянию.
+func (r *Recommendation) Today(origin models.Location, limit int) ([]models.Recommendation, error) {
+	if limit <= 0 {
+		limit = DefaultRecommendations
+	}
+	limit = min(limit, MaxRecommendations)
+	candidates, err := r.landmark.GetNearby(models.NearbyQuery{
+		Location: origin,
+		Radius:   RecommendationRadius,
+		Limit:    RecommendationCandidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(candidates))
+	for i, l := range candidates {
+		ids[i] = l.ID
+	}
+	forecasts, err := r.weather.GetWeatherByLandmarkIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]models.Recommendation, 0, len(candidates))
+	for _, l := range candidates {
+		today := summarizeToday(forecasts[l.ID], now, r.location)
+		suitability := 0.5
+		if scores, ok := categorySuitability[today.condition]; ok {
+			if s, ok := scores[strings.ToLower(strings.TrimSpace(l.Category))]; ok {
+				suitability = s
+			}
+		}
+		distance := 0.0
+		if l.Distance != nil {
+			distance = *l.Distance
+		}
+		proximity := 1 / (1 + distance/recommendationDistanceScale)
+		score := recommendationWeatherWeight*suitability + (1-recommendationWeatherWeight)*proximity
+		res = append(res, models.Recommendation{
+			Landmark:  l,
+			Score:     math.Round(score*1000) / 1000,
+			Condition: today.condition,
+			Reason:    recommendationReason(today, l, suitability, distance),
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Score > res[j].Score })
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// summarizeToday сводит интервалы прогноза до конца местных суток. Поздно
+// вечером, когда интервалов на сегодня не осталось, берутся ближайшие 12 часов.
+func summarizeToday(forecast []models.WeatherResponse, now time.Time, loc *time.Location) dayWeather {
+	local := now.In(loc)
+	end := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+	slots := make([]models.WeatherResponse, 0)
+	for _, slot := range forecast {
+		if slot.Date.Before(end) {
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) == 0 {
+		for _, slot := range forecast {
+			if slot.Date.Before(now.Add(12 * time.Hour)) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	if len(slots) == 0 {
+		return dayWeather{condition: models.ConditionUnknown}
+	}
+
+	day := dayWeather{tempMax: math.Inf(-1), tempMin: math.Inf(1)}
+	clear := 0
+	for _, slot := range slots {
+		day.tempMax = max(day.tempMax, slot.Temperature)
+		day.tempMin = min(day.tempMin, slot.Temperature)
+		day.popMax = max(day.popMax, slot.Pop)
+		day.rain += slot.Rain
+		day.windMax = max(day.windMax, slot.WindSpeed)
+		if icon := strings.TrimRight(slot.Icon, "dn"); icon == "01" || icon == "02" {
+			clear++
+		}
+	}
+	switch {
+	case day.popMax >= 0.5 || day.rain >= RainyDayThreshold:
+		day.condition = models.ConditionRain
+	case day.windMax >= 10:
+		day.condition = models.ConditionWindy
+	case day.tempMax >= 30:
+		day.condition = models.ConditionHot
+	case day.tempMax <= 5:
+		day.condition = models.ConditionCold
+	case clear*2 >= len(slots):
+		day.condition = models.ConditionClear
+	default:
+		day.condition = models.ConditionCloudy
+	}
+	return day
+}
+
+// recommendationReason формирует пояснение к рекомендации.
+func recommendationReason(day dayWeather, l models.Landmark, suitability, distance float64) string {
+	var weather string
+	switch day.condition {
+	case models.ConditionUnknown:
+		weather = "Прогноза на сегодня нет"
+	case models.ConditionRain:
+		weather = fmt.Sprintf(conditionPhrases[day.condition], day.popMax*100)
+	case models.ConditionWindy:
+		weather = fmt.Sprintf(conditionPhrases[day.condition], day.windMax)
+	default:
+		weather = fmt.Sprintf(conditionPhrases[day.condition], day.tempMax)
+	}
+	fit := "тоже можно посетить"
+	if phrase, ok := fitPhrases[day.condition]; ok && suitability >= 0.75 {
+		fit = phrase
+	}
+	category := strings.TrimSpace(l.Category)
+	if category == "" {
+		return fmt.Sprintf("%s — %s, %s от вас.", weather, fit, formatDistance(distance))
+	}
+	return fmt.Sprintf("%s — %s: %s, %s от вас.", weather, fit, strings.ToLower(category), formatDistance(distance))
+}
+
+func formatDistance(meters float64) string {
+	if meters < 1000 {
+		return fmt.Sprintf("%.0f м", math.Round(meters/10)*10)
+	}
+	return strings.Replace(fmt.Sprintf("%.1f км", meters/1000), ".", ",", 1)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"trailblazer/internal/models"
+)
+
+func TestSummarizeToday(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	// 10:00 по местному времени.
+	now := time.Date(2025, time.July, 2, 10, 0, 0, 0, loc)
+	slot := func(hour int, temp, pop, rain, wind float64, icon string) models.WeatherResponse {
+		return models.WeatherResponse{
+			Date:        time.Date(2025, time.July, 2, hour, 0, 0, 0, loc),
+			Temperature: temp, Pop: pop, Rain: rain, WindSpeed: wind, Icon: icon,
+		}
+	}
+	tests := []struct {
+		name     string
+		now      time.Time
+		forecast []models.WeatherResponse
+		want     dayWeather
+	}{
+		{
+			name: "no forecast",
+			want: dayWeather{condition: models.ConditionUnknown},
+		},
+		{
+			name:     "clear day",
+			forecast: []models.WeatherResponse{slot(12, 24, 0, 0, 3, "01d"), slot(15, 27, 0.1, 0, 4, "02d"), slot(18, 22, 0, 0, 2, "03d")},
+			want:     dayWeather{condition: models.ConditionClear, tempMax: 27, tempMin: 22, popMax: 0.1, windMax: 4},
+		},
+		{
+			name:     "cloudy day",
+			forecast: []models.WeatherResponse{slot(12, 20, 0, 0, 3, "04d"), slot(15, 21, 0, 0, 3, "03d"), slot(18, 19, 0, 0, 3, "01d")},
+			want:     dayWeather{condition: models.ConditionCloudy, tempMax: 21, tempMin: 19, windMax: 3},
+		},
+		{
+			name:     "rain by probability",
+			forecast: []models.WeatherResponse{slot(12, 20, 0.6, 0.2, 3, "10d"), slot(15, 22, 0.2, 0, 3, "01d")},
+			want:     dayWeather{condition: models.ConditionRain, tempMax: 22, tempMin: 20, popMax: 0.6, rain: 0.2, windMax: 3},
+		},
+		{
+			name:     "rain by amount",
+			forecast: []models.WeatherResponse{slot(12, 20, 0.3, 0.6, 3, "10d"), slot(15, 22, 0.3, 0.6, 3, "10d")},
+			want:     dayWeather{condition: models.ConditionRain, tempMax: 22, tempMin: 20, popMax: 0.3, rain: 1.2, windMax: 3},
+		},
+		{
+			name:     "windy beats heat",
+			forecast: []models.WeatherResponse{slot(12, 33, 0, 0, 12, "01d")},
+			want:     dayWeather{condition: models.ConditionWindy, tempMax: 33, tempMin: 33, windMax: 12},
+		},
+		{
+			name:     "hot",
+			forecast: []models.WeatherResponse{slot(12, 31, 0, 0, 2, "01d")},
+			want:     dayWeather{condition: models.ConditionHot, tempMax: 31, tempMin: 31, windMax: 2},
+		},
+		{
+			name:     "cold",
+			forecast: []models.WeatherResponse{slot(12, 4, 0, 0, 2, "01d")},
+			want:     dayWeather{condition: models.ConditionCold, tempMax: 4, tempMin: 4, windMax: 2},
+		},
+		{
+			name: "tomorrow is ignored",
+			forecast: []models.WeatherResponse{
+				slot(21, 18, 0, 0, 2, "01n"),
+				{Date: time.Date(2025, time.July, 3, 0, 0, 0, 0, loc), Temperature: 10, Pop: 0.9, Rain: 5, Icon: "10n"},
+			},
+			want: dayWeather{condition: models.ConditionClear, tempMax: 18, tempMin: 18, windMax: 2},
+		},
+		{
+			name: "next 12 hours when today is over",
+			now:  time.Date(2025, time.July, 2, 23, 30, 0, 0, loc),
+			forecast: []models.WeatherResponse{
+				{Date: time.Date(2025, time.July, 3, 3, 0, 0, 0, loc), Temperature: 15, Icon: "04n"},
+				{Date: time.Date(2025, time.July, 3, 12, 0, 0, 0, loc), Temperature: 25, Pop: 0.9, Icon: "10d"},
+			},
+			want: dayWeather{condition: models.ConditionCloudy, tempMax: 15, tempMin: 15},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := now
+			if !tt.now.IsZero() {
+				at = tt.now
+			}
+			if got := summarizeToday(tt.forecast, at, loc); got != tt.want {
+				t.Errorf("summarizeToday() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"trailblazer/internal/api"
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+	"trailblazer/internal/utils"
+)
+
+const (
+	// MaxRouteLandmarks ограничивает число точек в одном маршруте.
+	MaxRouteLandmarks = 50
+	// MaxMatrixLandmarks ограничивает размер матрицы расстояний.
+	MaxMatrixLandmarks = 25
+	// Ограничения поиска вдоль маршрута.
+	DefaultCorridorBufferKm = 2.0
+	MaxCorridorBufferKm     = 20.0
+	MaxCorridorPoints       = 5000
+)
+
+var ErrInvalidRoute = errors.New("invalid route request")
+
+type Route struct {
+	repo   repository.Landmark
+	router api.Router
+}
+
+func NewRouteService(landmark repository.Landmark, router api.Router) *Route {
+	return &Route{repo: landmark, router: router}
+}
+
+func (r *Route) Travel(fromID, toID int, mode models.TravelMode) (models.TravelEstimate, error) {
+	if err := validateTravelMode(mode); err != nil {
+		return models.TravelEstimate{}, err
+	}
+	landmarks, err := r.landmarksByIDs([]int{fromID, toID})
+	if err != nil {
+		return models.TravelEstimate{}, err
+	}
+	from, to := landmarks[0], landmarks[len(landmarks)-1]
+	estimate, err := r.router.Route(mode, from.Location, to.Location)
+	if err != nil {
+		return models.TravelEstimate{}, err
+	}
+	estimate.FromID, estimate.ToID = from.ID, to.ID
+	return estimate, nil
+}
+
+func (r *Route) TravelMatrix(req models.TravelMatrixRequest) (models.TravelMatrix, error) {
+	if err := validateTravelMode(req.Mode); err != nil {
+		return models.TravelMatrix{}, err
+	}
+	ids := uniqueIDs(req.IDs)
+	if len(ids) < 2 {
+		return models.TravelMatrix{}, fmt.Errorf("%w: at least 2 landmarks required", ErrInvalidRoute)
+	}
+	if len(ids) > MaxMatrixLandmarks {
+		return models.TravelMatrix{}, fmt.Errorf("%w: at most %d landmarks allowed", ErrInvalidRoute, MaxMatrixLandmarks)
+	}
+	landmarks, err := r.landmarksByIDs(ids)
+	if err != nil {
+		return models.TravelMatrix{}, err
+	}
+	points := make([]models.Location, len(landmarks))
+	for i, l := range landmarks {
+		points[i] = l.Location
+	}
+	matrix, err := r.router.Matrix(req.Mode, points)
+	if err != nil {
+		return models.TravelMatrix{}, err
+	}
+	matrix.IDs = ids
+	return matrix, nil
+}
+
+func (r *Route) Corridor(req models.CorridorRequest) ([]models.CorridorLandmark, error) {
+	path := req.Polyline
+	if req.EncodedPolyline != "" {
+		var err error
+		path, err = utils.DecodePolyline(req.EncodedPolyline, 5)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRoute, err)
+		}
+	}
+	if len(path) < 2 {
+		return nil, fmt.Errorf("%w: polyline must contain at least 2 points", ErrInvalidRoute)
+	}
+	if len(path) > MaxCorridorPoints {
+		return nil, fmt.Errorf("%w: at most %d polyline points allowed", ErrInvalidRoute, MaxCorridorPoints)
+	}
+	for _, p := range path {
+		if p.Lat < -90 || p.Lat > 90 || p.Lng < -180 || p.Lng > 180 {
+			return nil, fmt.Errorf("%w: invalid point %f,%f", ErrInvalidRoute, p.Lat, p.Lng)
+		}
+	}
+	buffer := req.BufferKm
+	if buffer <= 0 {
+		buffer = DefaultCorridorBufferKm
+	}
+	buffer = min(buffer, MaxCorridorBufferKm)
+	return r.repo.GetCorridor(path, buffer*1000, req.Categories)
+}
+
+func validateTravelMode(mode models.TravelMode) error {
+	switch mode {
+	case models.TravelModeCar, models.TravelModeFoot:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown travel mode %q", ErrInvalidRoute, mode)
+	}
+}
+
+func (r *Route) OptimizeRoute(req models.RouteOptimizeRequest) (models.OptimizedRoute, error) {
+	ids := uniqueIDs(req.IDs)
+	if len(ids) == 0 {
+		return models.OptimizedRoute{}, fmt.Errorf("%w: no landmarks provided", ErrInvalidRoute)
+	}
+	if len(ids) > MaxRouteLandmarks {
+		return models.OptimizedRoute{}, fmt.Errorf("%w: at most %d landmarks allowed", ErrInvalidRoute, MaxRouteLandmarks)
+	}
+	landmarks, err := r.landmarksByIDs(ids)
+	if err != nil {
+		return models.OptimizedRoute{}, err
+	}
+
+	points := make([]models.Location, 0, len(landmarks)+1)
+	if req.Start != nil {
+		points = append(points, *req.Start)
+	}
+	for _, l := range landmarks {
+		points = append(points, l.Location)
+	}
+	tour := optimizeTour(distanceMatrix(points), req.Start != nil, req.Closed)
+
+	route := models.OptimizedRoute{Start: req.Start, Closed: req.Closed}
+	// Узлы обхода нумеруют точки в points: при заданном старте узел 0 - это он.
+	offset := 0
+	if req.Start != nil {
+		offset = 1
+	}
+	nodeID := func(node int) int {
+		if node < offset {
+			return 0
+		}
+		return landmarks[node-offset].ID
+	}
+	for i, node := range tour {
+		if node >= offset {
+			l := landmarks[node-offset]
+			route.Order = append(route.Order, l.ID)
+			route.Landmarks = append(route.Landmarks, l)
+		}
+		if i == 0 {
+			continue
+		}
+		route.Legs = append(route.Legs, newRouteLeg(points, tour[i-1], node, nodeID))
+	}
+	if req.Closed && len(tour) > 1 {
+		route.Legs = append(route.Legs, newRouteLeg(points, tour[len(tour)-1], tour[0], nodeID))
+	}
+	for _, leg := range route.Legs {
+		route.TotalDistance += leg.Distance
+	}
+	return route, nil
+}
+
+func newRouteLeg(points []models.Location, from, to int, nodeID func(int) int) models.RouteLeg {
+	return models.RouteLeg{
+		FromID:   nodeID(from),
+		ToID:     nodeID(to),
+		Distance: utils.Haversine(points[from], points[to]),
+	}
+}
+
+// landmarksByIDs загружает достопримечательности в порядке ids и проверяет, что найдены все.
+func (r *Route) landmarksByIDs(ids []int) ([]models.Landmark, error) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	found, err := r.repo.GetLandmarksByIDs(args)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]models.Landmark, len(found))
+	for _, l := range found {
+		byID[l.ID] = l
+	}
+	landmarks := make([]models.Landmark, 0, len(ids))
+	var missing []int
+	for _, id := range ids {
+		l, ok := byID[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		landmarks = append(landmarks, l)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: landmarks not found: %v", ErrInvalidRoute, missing)
+	}
+	return landmarks, nil
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	res := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func distanceMatrix(points []models.Location) [][]float64 {
+	m := make([][]float64, len(points))
+	for i := range points {
+		m[i] = make([]float64, len(points))
+		for j := range points {
+			if i != j {
+				m[i][j] = utils.Haversine(points[i], points[j])
+			}
+		}
+	}
+	return m
+}
+
+// optimizeTour строит порядок обхода точек эвристикой ближайшего соседа и
+// улучшает его алгоритмом 2-opt. Если fixedStart, обход начинается с точки 0.
+func optimizeTour(dist [][]float64, fixedStart, closed bool) []int {
+	n := len(dist)
+	if n <= 2 {
+		tour := make([]int, n)
+		for i := range tour {
+			tour[i] = i
+		}
+		return tour
+	}
+	starts := []int{0}
+	if !fixedStart {
+		starts = starts[:0]
+		for i := 0; i < n; i++ {
+			starts = append(starts, i)
+		}
+	}
+	var best []int
+	bestCost := 0.0
+	for _, start := range starts {
+		tour := nearestNeighbourTour(dist, start)
+		twoOpt(dist, tour, fixedStart, closed)
+		if cost := tourCost(dist, tour, closed); best == nil || cost < bestCost {
+			best, bestCost = tour, cost
+		}
+	}
+	return best
+}
+
+func nearestNeighbourTour(dist [][]float64, start int) []int {
+	n := len(dist)
+	visited := make([]bool, n)
+	tour := make([]int, 0, n)
+	current := start
+	for len(tour) < n {
+		visited[current] = true
+		tour = append(tour, current)
+		next := -1
+		for j := 0; j < n; j++ {
+			if !visited[j] && (next == -1 || dist[current][j] < dist[current][next]) {
+				next = j
+			}
+		}
+		if next == -1 {
+			break
+		}
+		current = next
+	}
+	return tour
+}
+
+// twoOpt разворачивает участки обхода, пока это сокращает его длину.
+func twoOpt(dist [][]float64, tour []int, fixedStart, closed bool) {
+	n := len(tour)
+	edge := func(a, b int) float64 {
+		if a < 0 || b < 0 {
+			return 0
+		}
+		return dist[tour[a]][tour[b]]
+	}
+	first := 0
+	if fixedStart {
+		first = 1
+	}
+	for improved := true; improved; {
+		improved = false
+		for i := first; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				prev, next := i-1, j+1
+				if next == n {
+					next = -1
+				}
+				if closed {
+					if i == 0 && j == n-1 {
+						continue
+					}
+					if prev < 0 {
+						prev = n - 1
+					}
+					if next < 0 {
+						next = 0
+					}
+				}
+				delta := edge(prev, j) + edge(i, next) - edge(prev, i) - edge(j, next)
+				if delta < -1e-6 {
+					for a, b := i, j; a < b; a, b = a+1, b-1 {
+						tour[a], tour[b] = tour[b], tour[a]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+}
+
+func tourCost(dist [][]float64, tour []int, closed bool) float64 {
+	cost := 0.0
+	for i := 1; i < len(tour); i++ {
+		cost += dist[tour[i-1]][tour[i]]
+	}
+	if closed && len(tour) > 1 {
+		cost += dist[tour[len(tour)-1]][tour[0]]
+	}
+	return cost
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"trailblazer/internal/models"
+)
+
+// linePoints возвращает матрицу расстояний для точек на прямой.
+func linePoints(xs ...float64) [][]float64 {
+	dist := make([][]float64, len(xs))
+	for i := range xs {
+		dist[i] = make([]float64, len(xs))
+		for j := range xs {
+			dist[i][j] = math.Abs(xs[i] - xs[j])
+		}
+	}
+	return dist
+}
+
+func TestTwoOpt(t *testing.T) {
+	tests := []struct {
+		name       string
+		dist       [][]float64
+		tour       []int
+		fixedStart bool
+		closed     bool
+		want       float64
+	}{
+		{"open uncrossed", linePoints(0, 1, 2, 3), []int{0, 2, 1, 3}, true, false, 3},
+		{"open reversed tail", linePoints(0, 1, 2, 3, 4), []int{0, 4, 3, 2, 1}, true, false, 4},
+		{"open free start", linePoints(0, 1, 2, 3), []int{1, 3, 0, 2}, false, false, 3},
+		{"closed", linePoints(0, 1, 2, 3), []int{0, 2, 1, 3}, true, true, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tour := append([]int(nil), tt.tour...)
+			twoOpt(tt.dist, tour, tt.fixedStart, tt.closed)
+			if got := tourCost(tt.dist, tour, tt.closed); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cost after twoOpt = %v (tour %v), want %v", got, tour, tt.want)
+			}
+			if tt.fixedStart && tour[0] != tt.tour[0] {
+				t.Errorf("start moved from %d to %d", tt.tour[0], tour[0])
+			}
+		})
+	}
+}
+
+func TestOptimizeTourConvex(t *testing.T) {
+	// Для точек в выпуклом положении обход без самопересечений оптимален,
+	// поэтому 2-opt обязан найти периметр многоугольника.
+	order := []int{0, 5, 2, 7, 4, 1, 6, 3}
+	points := make([]models.Location, len(order))
+	for i, k := range order {
+		angle := 2 * math.Pi * float64(k) / float64(len(order))
+		points[i] = models.Location{Lat: 44.9 + 0.05*math.Sin(angle), Lng: 34.1 + 0.05*math.Cos(angle)}
+	}
+	dist := distanceMatrix(points)
+	perimeter := 0.0
+	for k := range order {
+		a, b := indexOf(order, k), indexOf(order, (k+1)%len(order))
+		perimeter += dist[a][b]
+	}
+
+	tests := []struct {
+		name       string
+		fixedStart bool
+	}{
+		{"fixed start", true},
+		{"free start", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tour := optimizeTour(dist, tt.fixedStart, true)
+			if len(tour) != len(points) {
+				t.Fatalf("tour has %d points, want %d", len(tour), len(points))
+			}
+			if tt.fixedStart && tour[0] != 0 {
+				t.Errorf("tour starts at %d, want 0", tour[0])
+			}
+			if got := tourCost(dist, tour, true); got > perimeter+1e-6 {
+				t.Errorf("tour cost = %v, want perimeter %v", got, perimeter)
+			}
+		})
+	}
+}
+
+func indexOf(values []int, v int) int {
+	for i, x := range values {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
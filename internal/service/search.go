@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+// searchCacheTTL ограничивает время жизни словаря синонимов и результатов
+// поиска в памяти, чтобы изменения с других экземпляров сервера тоже подхватывались.
+const searchCacheTTL = 10 * time.Minute
+
+// searchCache хранит словарь синонимов и результаты поиска. Кэш сбрасывается
+// целиком при любом изменении словаря.
+type searchCache struct {
+	repo repository.Synonym
+
+	mu       sync.RWMutex
+	loadedAt time.Time
+	phrases  map[string][]string
+	results  map[string][]models.Landmark
+}
+
+func newSearchCache(repo repository.Synonym) *searchCache {
+	return &searchCache{repo: repo, results: make(map[string][]models.Landmark)}
+}
+
+// Invalidate сбрасывает словарь и сохранённые результаты поиска.
+func (c *searchCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+	c.phrases = nil
+	c.results = make(map[string][]models.Landmark)
+}
+
+// Expand возвращает исходный запрос и все его варианты, полученные заменой
+// известных терминов на их синонимы.
+func (c *searchCache) Expand(q string) []string {
+	dictionary := c.dictionary()
+	normalized := normalizePhrase(q)
+	phrases := []string{normalized}
+	seen := map[string]bool{normalized: true}
+	padded := " " + normalized + " "
+	for phrase, alternatives := range dictionary {
+		if !strings.Contains(padded, " "+phrase+" ") {
+			continue
+		}
+		for _, alternative := range alternatives {
+			expanded := strings.TrimSpace(strings.Replace(padded, " "+phrase+" ", " "+alternative+" ", 1))
+			if !seen[expanded] {
+				seen[expanded] = true
+				phrases = append(phrases, expanded)
+			}
+		}
+	}
+	return phrases
+}
+
+func (c *searchCache) Get(key string) ([]models.Landmark, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if time.Since(c.loadedAt) > searchCacheTTL {
+		return nil, false
+	}
+	res, ok := c.results[key]
+	if !ok {
+		return nil, false
+	}
+	return copyLandmarks(res), true
+}
+
+func (c *searchCache) Set(key string, landmarks []models.Landmark) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[key] = copyLandmarks(landmarks)
+}
+
+// copyLandmarks копирует срез, чтобы вызывающий код не менял результаты в кэше.
+func copyLandmarks(landmarks []models.Landmark) []models.Landmark {
+	res := make([]models.Landmark, len(landmarks))
+	copy(res, landmarks)
+	return res
+}
+
+func (c *searchCache) dictionary() map[string][]string {
+	c.mu.RLock()
+	if c.phrases != nil && time.Since(c.loadedAt) <= searchCacheTTL {
+		defer c.mu.RUnlock()
+		return c.phrases
+	}
+	c.mu.RUnlock()
+
+	synonyms, err := c.repo.GetSynonyms()
+	if err != nil {
+		slog.Error(fmt.Sprintf("error with loading synonyms %v", err))
+		return nil
+	}
+	phrases := make(map[string][]string)
+	for _, synonym := range synonyms {
+		group := make([]string, 0, len(synonym.Synonyms)+1)
+		group = append(group, normalizePhrase(synonym.Term))
+		for _, s := range synonym.Synonyms {
+			group = append(group, normalizePhrase(s))
+		}
+		for _, phrase := range group {
+			for _, alternative := range group {
+				if alternative != phrase {
+					phrases[phrase] = append(phrases[phrase], alternative)
+				}
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.phrases = phrases
+	c.loadedAt = time.Now()
+	c.results = make(map[string][]models.Landmark)
+	return phrases
+}
+
+// normalizePhrase приводит фразу к нижнему регистру, заменяет «ё» на «е»,
+// дефисы на пробелы и схлопывает пробелы.
+func normalizePhrase(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer("ё", "е", "-", " ", "–", " ", "—", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func searchCacheKey(phrases []string, origin *models.Location) string {
+	key := strings.Join(phrases, "|")
+	if origin != nil {
+		key += fmt.Sprintf("@%v,%v", origin.Lat, origin.Lng)
+	}
+	return key
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+	"trailblazer/internal/utils"
+)
+
+// Searcher выполняет полнотекстовый поиск достопримечательностей по набору
+// равнозначных фраз (исходный запрос и его синонимы).
+type Searcher interface {
+	Search(phrases []string, origin *models.Location) ([]models.Landmark, error)
+	// Refresh вызывается после изменения данных о достопримечательностях.
+	Refresh() error
+}
+
+// NewSearcher создаёт реализацию поиска, выбранную в search.backend.
+func NewSearcher(cfg config.SearchConfig, landmark repository.Landmark) (Searcher, error) {
+	switch cfg.Backend {
+	case "", "postgres":
+		return NewPostgresSearcher(landmark, cfg.DistanceScale), nil
+	case "memory":
+		load := func() ([]models.Landmark, error) { return landmark.GetLandmarks(-1, nil) }
+		if cfg.Source != "" {
+			load = func() ([]models.Landmark, error) { return landmarksFromFile(cfg.Source) }
+		}
+		return NewIndexSearcher(load, cfg.DistanceScale)
+	default:
+		return nil, fmt.Errorf("unknown search backend: %s", cfg.Backend)
+	}
+}
+
+// DefaultSearchDistanceScale используется, если search.distance_scale не задан.
+const DefaultSearchDistanceScale = 20.0
+
+// PostgresSearcher ищет средствами полнотекстового поиска Postgres.
+type PostgresSearcher struct {
+	repo          repository.Landmark
+	distanceScale float64
+}
+
+func NewPostgresSearcher(landmark repository.Landmark, distanceScale float64) *PostgresSearcher {
+	return &PostgresSearcher{repo: landmark, distanceScale: searchDistanceScale(distanceScale)}
+}
+
+func (s *PostgresSearcher) Search(phrases []string, origin *models.Location) ([]models.Landmark, error) {
+	return s.repo.Search(phrases, origin, s.distanceScale)
+}
+
+func (s *PostgresSearcher) Refresh() error {
+	return nil
+}
+
+func searchDistanceScale(scale float64) float64 {
+	if scale <= 0 {
+		return DefaultSearchDistanceScale
+	}
+	return scale
+}
+
+// Веса полей совпадают с весами в запросе LandmarkDB.Search.
+const (
+	nameWeight    = 2.0
+	addressWeight = 1.0
+)
+
+type posting struct {
+	doc    int
+	weight float64
+}
+
+// IndexSearcher - инвертированный индекс в памяти процесса со стеммингом
+// русских слов. Не требует базы данных, если landmarks загружаются из файла.
+type IndexSearcher struct {
+	load          func() ([]models.Landmark, error)
+	distanceScale float64
+
+	mu       sync.RWMutex
+	docs     []models.Landmark
+	postings map[string][]posting
+}
+
+func NewIndexSearcher(load func() ([]models.Landmark, error), distanceScale float64) (*IndexSearcher, error) {
+	s := &IndexSearcher{load: load, distanceScale: searchDistanceScale(distanceScale)}
+	if err := s.Refresh(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh заново загружает достопримечательности и перестраивает индекс.
+func (s *IndexSearcher) Refresh() error {
+	landmarks, err := s.load()
+	if err != nil {
+		return fmt.Errorf("failed to load landmarks for search index: %w", err)
+	}
+	postings := make(map[string][]posting)
+	for i, landmark := range landmarks {
+		terms := make(map[string]float64)
+		for _, term := range indexTerms(landmark.Name) {
+			terms[term] += nameWeight
+		}
+		for _, term := range indexTerms(landmark.Address) {
+			terms[term] += addressWeight
+		}
+		for term, weight := range terms {
+			postings[term] = append(postings[term], posting{doc: i, weight: weight})
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs = landmarks
+	s.postings = postings
+	return nil
+}
+
+func (s *IndexSearcher) Search(phrases []string, origin *models.Location) ([]models.Landmark, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scores := make(map[int]float64)
+	for _, phrase := range phrases {
+		for doc, score := range s.matchPhrase(indexTerms(phrase)) {
+			scores[doc] = math.Max(scores[doc], score)
+		}
+	}
+
+	type hit struct {
+		landmark models.Landmark
+		score    float64
+	}
+	hits := make([]hit, 0, len(scores))
+	for doc, score := range scores {
+		landmark := s.docs[doc]
+		if origin != nil && hasLocation(landmark) {
+			distance := utils.Haversine(*origin, landmark.Location)
+			landmark.Distance = &distance
+			score /= 1 + distance/1000/s.distanceScale
+		}
+		hits = append(hits, hit{landmark: landmark, score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].landmark.ID < hits[j].landmark.ID
+	})
+
+	landmarks := make([]models.Landmark, len(hits))
+	for i, h := range hits {
+		landmarks[i] = h.landmark
+	}
+	return landmarks, nil
+}
+
+// matchPhrase возвращает документы, содержащие все термы фразы, с оценкой tf-idf.
+func (s *IndexSearcher) matchPhrase(terms []string) map[int]float64 {
+	if len(terms) == 0 {
+		return nil
+	}
+	var scores map[int]float64
+	for _, term := range terms {
+		list := s.postings[term]
+		if len(list) == 0 {
+			return nil
+		}
+		idf := math.Log(1 + float64(len(s.docs))/float64(len(list)))
+		next := make(map[int]float64, len(list))
+		for _, p := range list {
+			if scores == nil {
+				next[p.doc] = p.weight * idf
+			} else if score, ok := scores[p.doc]; ok {
+				next[p.doc] = score + p.weight*idf
+			}
+		}
+		scores = next
+	}
+	return scores
+}
+
+// indexTerms разбивает текст на слова и приводит их к основе.
+func indexTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, utils.StemRussian(word))
+	}
+	return terms
+}
+
+// hasLocation сообщает, известны ли координаты достопримечательности. Записи
+// без координат ранжируются без учёта расстояния.
+func hasLocation(landmark models.Landmark) bool {
+	return landmark.Lat != 0 || landmark.Lng != 0
+}
+
+// landmarksFromFile читает достопримечательности из landmarks.json офлайн-пакета
+// (cmd/bundle): из самого zip-архива или из распакованного файла. Записям без id
+// присваиваются порядковые номера.
+func landmarksFromFile(path string) ([]models.Landmark, error) {
+	data, err := readLandmarksFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var landmarks []models.Landmark
+	if err := json.Unmarshal(data, &landmarks); err != nil {
+		return nil, err
+	}
+	for i := range landmarks {
+		if landmarks[i].ID == 0 {
+			landmarks[i].ID = i + 1
+		}
+	}
+	return landmarks, nil
+}
+
+func readLandmarksFile(path string) ([]byte, error) {
+	if filepath.Ext(path) != ".zip" {
+		return os.ReadFile(path)
+	}
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+	file, err := archive.Open("landmarks.json")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// benchmarkPhrases - типичные запросы с синонимами, как их разворачивает searchCache.
+var benchmarkPhrases = [][]string{
+	{"ласточкино гнездо"},
+	{"музей", "музеи"},
+	{"набережная ялты", "набережная ялта"},
+	{"пещера"},
+}
+
+var benchmarkOrigin = &models.Location{Lat: 44.95, Lng: 34.1}
+
+func benchmarkLandmarks(n int) []models.Landmark {
+	names := []string{"Музей", "Ласточкино гнездо", "Пещера", "Набережная", "Дворец", "Парк", "Водопад", "Крепость"}
+	cities := []string{"Ялта", "Симферополь", "Севастополь", "Феодосия", "Алушта"}
+	landmarks := make([]models.Landmark, n)
+	for i := range landmarks {
+		landmarks[i] = models.Landmark{
+			ID:       i + 1,
+			Name:     fmt.Sprintf("%s %d", names[i%len(names)], i),
+			Address:  fmt.Sprintf("%s, ул. Ленина, %d", cities[i%len(cities)], i),
+			Location: models.Location{Lat: 44.4 + float64(i%100)/100, Lng: 33.5 + float64(i%150)/100},
+		}
+	}
+	return landmarks
+}
+
+func BenchmarkIndexSearcher(b *testing.B) {
+	landmarks := benchmarkLandmarks(5000)
+	s, err := NewIndexSearcher(func() ([]models.Landmark, error) { return landmarks, nil }, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Search(benchmarkPhrases[i%len(benchmarkPhrases)], benchmarkOrigin); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPostgresSearcher запускается на базе из TEST_DATABASE_URL с применёнными миграциями.
+func BenchmarkPostgresSearcher(b *testing.B) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	s := NewPostgresSearcher(repository.NewLandmarkPostgres(context.Background(), db), 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Search(benchmarkPhrases[i%len(benchmarkPhrases)], benchmarkOrigin); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestIndexSearcherWithoutLocation(t *testing.T) {
+	landmarks := []models.Landmark{
+		{ID: 1, Name: "Музей истории"},
+		{ID: 2, Name: "Музей", Location: models.Location{Lat: 44.95, Lng: 34.1}},
+	}
+	s, err := NewIndexSearcher(func() ([]models.Landmark, error) { return landmarks, nil }, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Search([]string{"музеи"}, benchmarkOrigin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("got %d results, want 2", len(res))
+	}
+	for _, l := range res {
+		if l.ID == 1 && l.Distance != nil {
+			t.Errorf("landmark without location got distance %v", *l.Distance)
+		}
+		if l.ID == 2 && l.Distance == nil {
+			t.Errorf("landmark with location has no distance")
+		}
+	}
+}
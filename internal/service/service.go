@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
+	"trailblazer/internal/api"
 	"trailblazer/internal/config"
 	"trailblazer/internal/models"
 	"trailblazer/internal/repository"
@@ -16,6 +19,15 @@ type Service struct {
 	LandmarkService
 	WeatherService
 	UserService
+	SynonymService
+	TileService
+	RouteService
+	TrailService
+	CheckInService
+	BundleService
+	SyncService
+	JobService
+	RecommendationService
 }
 
 type UserService interface {
@@ -25,28 +37,107 @@ type UserService interface {
 	GetReview(name string, onlyPhoto bool) (map[int]models.ReviewByUser, error)
 	GetProfile(c context.Context, userID int64) (*models.Profile, error)
 	UpdateUserProfile(c context.Context, i int, username string, bytes []byte, bio string) error
+	IsAdmin(c context.Context, userID int64) (bool, error)
 }
 type LandmarkService interface {
 	GetFacilities(bbox models.BBOX) ([]models.Landmark, error)
+	GetFacilityClusters(bbox models.BBOX) (models.FacilityClusters, error)
 	GetLandmarks(page int, categories []string) ([]models.Landmark, error)
 	GetLandmarksByIDs(ids []int) ([]models.Landmark, error)
-	Search(q string) ([]models.Landmark, error)
+	Search(q string, origin *models.Location) ([]models.Landmark, error)
 	UpdateImagePath(place, path string) error
 	GetLandmarksByName(name string) (models.Landmark, error)
 	GetLandmarksByCategories(categories []string) ([]models.Landmark, error)
+	GetNearby(q models.NearbyQuery) ([]models.Landmark, error)
+}
+type SynonymService interface {
+	GetSynonyms() ([]models.Synonym, error)
+	AddSynonym(synonym models.Synonym) (int, error)
+	UpdateSynonym(synonym models.Synonym) error
+	DeleteSynonym(id int) error
+}
+type TileService interface {
+	GetTile(z, x, y int) ([]byte, error)
+	InvalidateTiles() error
+}
+type RouteService interface {
+	OptimizeRoute(req models.RouteOptimizeRequest) (models.OptimizedRoute, error)
+	Travel(fromID, toID int, mode models.TravelMode) (models.TravelEstimate, error)
+	TravelMatrix(req models.TravelMatrixRequest) (models.TravelMatrix, error)
+	Corridor(req models.CorridorRequest) ([]models.CorridorLandmark, error)
+}
+type TrailService interface {
+	ImportGPX(data []byte, meta models.TrailImport) (models.Trail, error)
+	GetTrail(slug string) (models.Trail, error)
+	GetTrailsNearLandmark(name string, radius float64) ([]models.Trail, error)
+	GetTrailsInBBOX(bbox models.BBOX) ([]models.Trail, error)
+}
+type CheckInService interface {
+	CheckIn(userID int64, req models.CheckInRequest) (models.CheckIn, error)
+	GetVisits(userID int64) ([]models.Visit, error)
+}
+type BundleService interface {
+	Regions() []string
+	GetBundle(region string) (models.BundleManifest, string, error)
+	BuildBundle(region string) (models.BundleManifest, []byte, error)
+	InvalidateBundles() error
+}
+type SyncService interface {
+	Changes(since string) (models.SyncResponse, error)
+}
+type RecommendationService interface {
+	Today(origin models.Location, limit int) ([]models.Recommendation, error)
+}
+type JobService interface {
+	StartJobs(ctx context.Context)
+	Jobs() []models.JobStatus
+	JobStatus(name string) (models.JobStatus, error)
+	TriggerJob(name string) error
 }
 type WeatherService interface {
-	SetWeather(id int, forecast models.WeatherForecast) error
+	SetWeather(id int, forecast models.LocationForecast) error
 	GetWeatherByLandmarkID(id int) (*[]models.WeatherResponse, error)
+	GetWeatherByLandmarkIDs(ids []int) (map[int][]models.WeatherResponse, error)
+	Refresh(ctx context.Context, landmarks []models.Landmark) (models.WeatherRefreshStats, error)
+	DailySummary(forecast []models.WeatherResponse) []models.DailyWeather
+	Rollup() (models.WeatherRollupStats, error)
+	GetClimate(landmarkID int) (models.Climate, error)
+	BestTime(landmark models.Landmark, limit int) (models.BestTime, error)
 }
 
 func NewService(ctx context.Context, repository *repository.Repository, tokenMaker utils.Maker, hashUtil utils.Hasher, cfg config.Config) *Service {
+	cache := newSearchCache(repository.Synonym)
+	tiles := NewTileService(repository.Landmark, repository.Sync, cfg.TileConfig)
+	bundles := NewBundleService(repository.Landmark, repository.Sync, cfg.BundleConfig)
+	searcher, err := NewSearcher(cfg.SearchConfig, repository.Landmark)
+	if err != nil {
+		slog.Error(fmt.Sprintf("failed to initialize searcher, falling back to postgres: %v", err))
+		searcher = NewPostgresSearcher(repository.Landmark, cfg.SearchConfig.DistanceScale)
+	}
+	provider, err := api.NewWeatherProvider(cfg.WeatherConfig)
+	if err != nil {
+		slog.Error(fmt.Sprintf("failed to initialize weather provider: %v", err))
+	}
+	weather := NewWeatherService(repository.Weather, provider, cfg.WeatherConfig)
 	return &Service{
 		repository: repository,
 		ctx:        ctx,
 
-		LandmarkService: NewLandmarkService(repository.Landmark, cfg.ParserConfig),
-		WeatherService:  NewWeatherService(repository.Weather, cfg.WeatherConfig),
+		LandmarkService: NewLandmarkService(repository.Landmark, cfg.ParserConfig, cache, searcher, tiles, bundles),
+		WeatherService:  weather,
 		UserService:     NewUserService(repository.User),
+		SynonymService:  NewSynonymService(repository.Synonym, cache),
+		TileService:     tiles,
+		RouteService:    NewRouteService(repository.Landmark, api.NewRouter(cfg.RoutingConfig)),
+		TrailService:    NewTrailService(repository.Trail, repository.Landmark),
+		CheckInService:  NewCheckInService(repository.CheckIn, repository.Landmark, cfg.CheckInConfig),
+		BundleService:   bundles,
+		SyncService:     NewSyncService(repository.Sync, repository.Landmark),
+
+		RecommendationService: NewRecommendationService(repository.Landmark, weather, cfg.WeatherConfig.Timezone),
+		JobService: NewScheduler(
+			NewWeatherJob(weather, repository.Landmark, cfg.WeatherConfig.RefreshInterval),
+			NewWeatherRetentionJob(weather, cfg.WeatherConfig.RetentionInterval),
+		),
 	}
 }
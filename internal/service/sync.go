@@ -0,0 +1,152 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+// SyncPageSize - наибольшее число записей журнала, обрабатываемых за один запрос.
+const SyncPageSize = 1000
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Sync struct {
+	repo     repository.Sync
+	landmark repository.Landmark
+}
+
+func NewSyncService(sync repository.Sync, landmark repository.Landmark) *Sync {
+	return &Sync{repo: sync, landmark: landmark}
+}
+
+// Changes возвращает изменения после курсора since. Пустой курсор означает
+// полную выгрузку. Несколько изменений одной сущности схлопываются в одно:
+// созданная и затем удалённая сущность не попадает в ответ.
+func (s *Sync) Changes(since string) (models.SyncResponse, error) {
+	cursor, err := parseSyncCursor(since)
+	if err != nil {
+		return models.SyncResponse{}, err
+	}
+	changes, err := s.repo.GetChanges(cursor, SyncPageSize+1)
+	if err != nil {
+		return models.SyncResponse{}, err
+	}
+	res := models.SyncResponse{HasMore: len(changes) > SyncPageSize}
+	if res.HasMore {
+		changes = changes[:SyncPageSize]
+	}
+	if len(changes) > 0 {
+		last := changes[len(changes)-1]
+		cursor = models.SyncCursor{TxID: last.TxID, ID: last.ID}
+	}
+	res.Cursor = formatSyncCursor(cursor)
+
+	collapsed := collapseChanges(changes)
+	landmarkOps := collapsed[models.SyncEntityLandmark]
+	res.Images = syncKeys(collapsed[models.SyncEntityImage])
+	res.Categories = syncKeys(collapsed[models.SyncEntityCategory])
+
+	res.Landmarks = models.SyncLandmarks{
+		Created: make([]models.Landmark, 0),
+		Updated: make([]models.Landmark, 0),
+		Deleted: make([]int, 0),
+	}
+	var ids []any
+	for key, op := range landmarkOps {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		if op == models.SyncOpDelete {
+			res.Landmarks.Deleted = append(res.Landmarks.Deleted, id)
+		} else {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(res.Landmarks.Deleted)
+	landmarks, err := s.landmark.GetLandmarksByIDs(ids)
+	if err != nil {
+		return models.SyncResponse{}, err
+	}
+	sort.Slice(landmarks, func(i, j int) bool { return landmarks[i].ID < landmarks[j].ID })
+	for _, l := range landmarks {
+		if landmarkOps[strconv.Itoa(l.ID)] == models.SyncOpInsert {
+			res.Landmarks.Created = append(res.Landmarks.Created, l)
+		} else {
+			res.Landmarks.Updated = append(res.Landmarks.Updated, l)
+		}
+	}
+	return res, nil
+}
+
+// parseSyncCursor разбирает курсор вида "<txid>-<id>". Пустой курсор - начало журнала.
+func parseSyncCursor(s string) (models.SyncCursor, error) {
+	if s == "" {
+		return models.SyncCursor{}, nil
+	}
+	tx, id, ok := strings.Cut(s, "-")
+	if !ok {
+		return models.SyncCursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, s)
+	}
+	var cursor models.SyncCursor
+	var errTx, errID error
+	cursor.TxID, errTx = strconv.ParseInt(tx, 10, 64)
+	cursor.ID, errID = strconv.ParseInt(id, 10, 64)
+	if errTx != nil || errID != nil || cursor.TxID < 0 || cursor.ID < 0 {
+		return models.SyncCursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, s)
+	}
+	return cursor, nil
+}
+
+func formatSyncCursor(c models.SyncCursor) string {
+	return strconv.FormatInt(c.TxID, 10) + "-" + strconv.FormatInt(c.ID, 10)
+}
+
+// collapseChanges сводит журнал к итоговой операции для каждой сущности.
+func collapseChanges(changes []models.Change) map[string]map[string]string {
+	res := make(map[string]map[string]string)
+	for _, c := range changes {
+		ops, ok := res[c.Entity]
+		if !ok {
+			ops = make(map[string]string)
+			res[c.Entity] = ops
+		}
+		prev, seen := ops[c.Key]
+		switch {
+		case !seen:
+			ops[c.Key] = c.Op
+		case c.Op == models.SyncOpDelete && prev == models.SyncOpInsert:
+			delete(ops, c.Key)
+		case c.Op == models.SyncOpDelete:
+			ops[c.Key] = models.SyncOpDelete
+		case prev == models.SyncOpDelete:
+			// Сущность удалили и создали заново: для клиента это изменение.
+			ops[c.Key] = models.SyncOpUpdate
+		}
+	}
+	return res
+}
+
+func syncKeys(ops map[string]string) models.SyncKeys {
+	keys := models.SyncKeys{Created: make([]string, 0), Updated: make([]string, 0), Deleted: make([]string, 0)}
+	for key, op := range ops {
+		switch op {
+		case models.SyncOpInsert:
+			keys.Created = append(keys.Created, key)
+		case models.SyncOpUpdate:
+			keys.Updated = append(keys.Updated, key)
+		case models.SyncOpDelete:
+			keys.Deleted = append(keys.Deleted, key)
+		}
+	}
+	sort.Strings(keys.Created)
+	sort.Strings(keys.Updated)
+	sort.Strings(keys.Deleted)
+	return keys
+}
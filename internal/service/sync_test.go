@@ -0,0 +1,203 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+func TestParseSyncCursor(t *testing.T) {
+	tests := []struct {
+		cursor  string
+		want    models.SyncCursor
+		wantErr bool
+	}{
+		{cursor: "", want: models.SyncCursor{}},
+		{cursor: "0-0", want: models.SyncCursor{}},
+		{cursor: "7541-120", want: models.SyncCursor{TxID: 7541, ID: 120}},
+		{cursor: "120", wantErr: true},
+		{cursor: "a-1", wantErr: true},
+		{cursor: "1-b", wantErr: true},
+		{cursor: "1--2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cursor, func(t *testing.T) {
+			got, err := parseSyncCursor(tt.cursor)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Fatalf("parseSyncCursor(%q) error = %v, want ErrInvalidCursor", tt.cursor, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSyncCursor(%q) error = %v", tt.cursor, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSyncCursor(%q) = %+v, want %+v", tt.cursor, got, tt.want)
+			}
+			if tt.cursor != "" && formatSyncCursor(got) != tt.cursor {
+				t.Errorf("formatSyncCursor(%+v) = %q, want %q", got, formatSyncCursor(got), tt.cursor)
+			}
+		})
+	}
+}
+
+func TestCollapseChanges(t *testing.T) {
+	change := func(id int64, entity, key, op string) models.Change {
+		return models.Change{ID: id, TxID: 1, Entity: entity, Key: key, Op: op}
+	}
+	tests := []struct {
+		name    string
+		changes []models.Change
+		want    map[string]string
+	}{
+		{
+			name:    "single insert",
+			changes: []models.Change{change(1, "landmark", "1", "insert")},
+			want:    map[string]string{"1": "insert"},
+		},
+		{
+			name: "insert then update stays insert",
+			changes: []models.Change{
+				change(1, "landmark", "1", "insert"),
+				change(2, "landmark", "1", "update"),
+			},
+			want: map[string]string{"1": "insert"},
+		},
+		{
+			name: "insert, update, delete cancel out",
+			changes: []models.Change{
+				change(1, "landmark", "1", "insert"),
+				change(2, "landmark", "1", "update"),
+				change(3, "landmark", "1", "delete"),
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "update then delete",
+			changes: []models.Change{
+				change(1, "landmark", "1", "update"),
+				change(2, "landmark", "1", "delete"),
+			},
+			want: map[string]string{"1": "delete"},
+		},
+		{
+			name: "delete then insert is update",
+			changes: []models.Change{
+				change(1, "landmark", "1", "delete"),
+				change(2, "landmark", "1", "insert"),
+			},
+			want: map[string]string{"1": "update"},
+		},
+		{
+			name: "keys are independent",
+			changes: []models.Change{
+				change(1, "landmark", "1", "insert"),
+				change(2, "landmark", "2", "update"),
+				change(3, "landmark", "1", "delete"),
+			},
+			want: map[string]string{"2": "update"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collapseChanges(tt.changes)[models.SyncEntityLandmark]
+			if len(got) != len(tt.want) {
+				t.Fatalf("collapseChanges() = %v, want %v", got, tt.want)
+			}
+			for key, op := range tt.want {
+				if got[key] != op {
+					t.Errorf("collapseChanges()[%s] = %q, want %q", key, got[key], op)
+				}
+			}
+		})
+	}
+}
+
+// fakeSyncRepo отдаёт журнал, упорядоченный по (txid, id), начиная после курсора.
+type fakeSyncRepo struct {
+	repository.Sync
+	changes []models.Change
+	version string
+}
+
+func (r *fakeSyncRepo) GetVersion() (string, error) {
+	if r.version == "" {
+		return "", errors.New("no version")
+	}
+	return r.version, nil
+}
+
+func (r *fakeSyncRepo) GetChanges(since models.SyncCursor, limit int) ([]models.Change, error) {
+	var res []models.Change
+	for _, c := range r.changes {
+		if c.TxID > since.TxID || (c.TxID == since.TxID && c.ID > since.ID) {
+			res = append(res, c)
+		}
+		if len(res) == limit {
+			break
+		}
+	}
+	return res, nil
+}
+
+// fakeLandmarkRepo хранит достопримечательности в памяти.
+type fakeLandmarkRepo struct {
+	repository.Landmark
+	landmarks map[int]models.Landmark
+}
+
+func (r *fakeLandmarkRepo) GetLandmarksByIDs(ids []any) ([]models.Landmark, error) {
+	var res []models.Landmark
+	for _, id := range ids {
+		if l, ok := r.landmarks[id.(int)]; ok {
+			res = append(res, l)
+		}
+	}
+	return res, nil
+}
+
+func TestSyncChanges(t *testing.T) {
+	// Запись с меньшим id зафиксирована в более поздней транзакции.
+	changes := []models.Change{
+		{ID: 1, TxID: 10, Entity: "landmark", Key: "1", Op: "insert"},
+		{ID: 3, TxID: 11, Entity: "landmark", Key: "2", Op: "insert"},
+		{ID: 2, TxID: 12, Entity: "landmark", Key: "1", Op: "update"},
+		{ID: 4, TxID: 12, Entity: "landmark", Key: "3", Op: "delete"},
+	}
+	landmarks := map[int]models.Landmark{1: {ID: 1}, 2: {ID: 2}}
+	s := NewSyncService(&fakeSyncRepo{changes: changes}, &fakeLandmarkRepo{landmarks: landmarks})
+
+	first, err := s.Changes("")
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	if first.Cursor != "12-4" || first.HasMore {
+		t.Fatalf("Changes() cursor = %q, has_more = %v, want 12-4, false", first.Cursor, first.HasMore)
+	}
+	if len(first.Landmarks.Created) != 2 || len(first.Landmarks.Updated) != 0 ||
+		len(first.Landmarks.Deleted) != 1 || first.Landmarks.Deleted[0] != 3 {
+		t.Errorf("Changes() landmarks = %+v", first.Landmarks)
+	}
+
+	next, err := s.Changes("11-3")
+	if err != nil {
+		t.Fatalf("Changes(11-3) error = %v", err)
+	}
+	if next.Cursor != "12-4" {
+		t.Errorf("Changes(11-3) cursor = %q, want 12-4", next.Cursor)
+	}
+	if len(next.Landmarks.Updated) != 1 || next.Landmarks.Updated[0].ID != 1 || len(next.Landmarks.Created) != 0 {
+		t.Errorf("Changes(11-3) landmarks = %+v, want landmark 1 updated", next.Landmarks)
+	}
+
+	empty, err := s.Changes("12-4")
+	if err != nil {
+		t.Fatalf("Changes(12-4) error = %v", err)
+	}
+	if empty.Cursor != "12-4" || len(empty.Landmarks.Updated)+len(empty.Landmarks.Created)+len(empty.Landmarks.Deleted) != 0 {
+		t.Errorf("Changes(12-4) = %+v, want no changes and the same cursor", empty)
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+type Synonym struct {
+	repo  repository.Synonym
+	cache *searchCache
+}
+
+func NewSynonymService(synonym repository.Synonym, cache *searchCache) *Synonym {
+	return &Synonym{
+		repo:  synonym,
+		cache: cache,
+	}
+}
+
+func (s *Synonym) GetSynonyms() ([]models.Synonym, error) {
+	return s.repo.GetSynonyms()
+}
+
+func (s *Synonym) AddSynonym(synonym models.Synonym) (int, error) {
+	id, err := s.repo.AddSynonym(synonym)
+	if err != nil {
+		return 0, err
+	}
+	s.cache.Invalidate()
+	return id, nil
+}
+
+func (s *Synonym) UpdateSynonym(synonym models.Synonym) error {
+	if err := s.repo.UpdateSynonym(synonym); err != nil {
+		return err
+	}
+	s.cache.Invalidate()
+	return nil
+}
+
+func (s *Synonym) DeleteSynonym(id int) error {
+	if err := s.repo.DeleteSynonym(id); err != nil {
+		return err
+	}
+	s.cache.Invalidate()
+	return nil
+}
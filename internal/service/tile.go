@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/repository"
+)
+
+// MaxTileZoom - максимальный уровень масштаба, для которого отдаются тайлы.
+const MaxTileZoom = 22
+
+var ErrInvalidTile = errors.New("invalid tile coordinates")
+
+// Tile отдаёт векторные тайлы и кэширует их на диске в каталоге текущей
+// версии данных, поэтому кэш сбрасывается при изменении достопримечательностей.
+type Tile struct {
+	repo     repository.Landmark
+	versions *dataVersion
+	config.TileConfig
+
+	mu      sync.RWMutex
+	version string
+}
+
+func NewTileService(landmark repository.Landmark, changes repository.Sync, cfg config.TileConfig) *Tile {
+	return &Tile{
+		repo:       landmark,
+		versions:   newDataVersion(changes),
+		TileConfig: cfg,
+	}
+}
+
+func (t *Tile) GetTile(z, x, y int) ([]byte, error) {
+	if z < 0 || z > MaxTileZoom || x < 0 || y < 0 || x >= 1<<z || y >= 1<<z {
+		return nil, ErrInvalidTile
+	}
+	version := t.versions.Current()
+	if t.CacheDir == "" || version == "" {
+		return t.repo.GetTile(z, x, y)
+	}
+	path := filepath.Join(t.CacheDir, version, strconv.Itoa(z), strconv.Itoa(x), strconv.Itoa(y)+".mvt")
+
+	t.mu.RLock()
+	info, err := os.Stat(path)
+	if err == nil && (t.CacheTTL <= 0 || time.Since(info.ModTime()) < t.CacheTTL) {
+		tile, err := os.ReadFile(path)
+		t.mu.RUnlock()
+		if err == nil {
+			return tile, nil
+		}
+	} else {
+		t.mu.RUnlock()
+	}
+
+	tile, err := t.repo.GetTile(z, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.version != version {
+		removeStaleVersions(t.CacheDir, version)
+		t.version = version
+	}
+	if err := writeFileAtomic(path, tile); err != nil {
+		slog.Error(fmt.Sprintf("failed to cache tile %s: %v", path, err))
+	}
+	return tile, nil
+}
+
+// InvalidateTiles удаляет все закэшированные тайлы.
+func (t *Tile) InvalidateTiles() error {
+	if t.CacheDir == "" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return os.RemoveAll(t.CacheDir)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
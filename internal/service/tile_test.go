@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"trailblazer/internal/config"
+)
+
+// tileRepo считает обращения к базе за тайлами.
+type tileRepo struct {
+	fakeLandmarkRepo
+	calls int
+}
+
+func (r *tileRepo) GetTile(z, x, y int) ([]byte, error) {
+	r.calls++
+	return []byte(fmt.Sprintf("%d/%d/%d#%d", z, x, y, r.calls)), nil
+}
+
+func TestGetTileBounds(t *testing.T) {
+	repo := &tileRepo{}
+	s := NewTileService(repo, &fakeSyncRepo{version: "1-1"}, config.TileConfig{CacheDir: t.TempDir()})
+	tests := []struct {
+		z, x, y int
+		wantErr bool
+	}{
+		{0, 0, 0, false},
+		{2, 3, 3, false},
+		{MaxTileZoom, 1<<MaxTileZoom - 1, 0, false},
+		{-1, 0, 0, true},
+		{MaxTileZoom + 1, 0, 0, true},
+		{0, 1, 0, true},
+		{2, 4, 0, true},
+		{2, 0, 4, true},
+		{2, -1, 0, true},
+		{2, 0, -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d/%d/%d", tt.z, tt.x, tt.y), func(t *testing.T) {
+			calls := repo.calls
+			_, err := s.GetTile(tt.z, tt.x, tt.y)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTile) {
+					t.Fatalf("GetTile() error = %v, want ErrInvalidTile", err)
+				}
+				if repo.calls != calls {
+					t.Error("invalid tile was requested from the database")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTile() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGetTileCache(t *testing.T) {
+	dir := t.TempDir()
+	repo := &tileRepo{}
+	changes := &fakeSyncRepo{version: "10-3"}
+	s := NewTileService(repo, changes, config.TileConfig{CacheDir: dir, CacheTTL: time.Hour})
+	path := filepath.Join(dir, "10-3", "2", "1", "3.mvt")
+
+	get := func(want string, wantCalls int) {
+		t.Helper()
+		tile, err := s.GetTile(2, 1, 3)
+		if err != nil {
+			t.Fatalf("GetTile() error = %v", err)
+		}
+		if string(tile) != want || repo.calls != wantCalls {
+			t.Fatalf("GetTile() = %q after %d calls, want %q after %d", tile, repo.calls, want, wantCalls)
+		}
+	}
+
+	// Промах: тайл берётся из базы и сохраняется в каталоге версии.
+	get("2/1/3#1", 1)
+	if data, err := os.ReadFile(path); err != nil || string(data) != "2/1/3#1" {
+		t.Fatalf("cached tile = %q, %v", data, err)
+	}
+	// Попадание.
+	get("2/1/3#1", 1)
+
+	// Устаревший тайл запрашивается заново.
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	get("2/1/3#2", 2)
+	get("2/1/3#2", 2)
+
+	// Сброс кэша.
+	if err := s.InvalidateTiles(); err != nil {
+		t.Fatalf("InvalidateTiles() error = %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("cache dir still exists after InvalidateTiles: %v", err)
+	}
+	get("2/1/3#3", 3)
+	get("2/1/3#3", 3)
+
+	// Новая версия данных: кэш прежней версии не используется и удаляется.
+	changes.version = "11-4"
+	s.versions.checkedAt = time.Time{}
+	get("2/1/3#4", 4)
+	if _, err := os.Stat(filepath.Join(dir, "10-3")); !os.IsNotExist(err) {
+		t.Errorf("stale version dir was not removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "11-4", "2", "1", "3.mvt")); err != nil {
+		t.Errorf("tile was not cached for the new version: %v", err)
+	}
+}
+
+func TestGetTileWithoutCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		cacheDir bool
+		version  string
+	}{
+		{name: "no cache dir", version: "1-1"},
+		{name: "unknown version", cacheDir: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.TileConfig{}
+			if tt.cacheDir {
+				cfg.CacheDir = t.TempDir()
+			}
+			repo := &tileRepo{}
+			s := NewTileService(repo, &fakeSyncRepo{version: tt.version}, cfg)
+			for i := 1; i <= 2; i++ {
+				if _, err := s.GetTile(1, 1, 1); err != nil {
+					t.Fatalf("GetTile() error = %v", err)
+				}
+				if repo.calls != i {
+					t.Fatalf("database calls = %d, want %d", repo.calls, i)
+				}
+			}
+			if cfg.CacheDir != "" {
+				if entries, _ := os.ReadDir(cfg.CacheDir); len(entries) != 0 {
+					t.Errorf("cache dir is not empty: %v", entries)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+	"trailblazer/internal/utils"
+)
+
+const (
+	// DefaultTrailRadius - расстояние в метрах, на котором тропа считается проходящей рядом.
+	DefaultTrailRadius = 1000.0
+	MaxTrailRadius     = 10000.0
+	// elevationThreshold отсекает шум высот GPS при подсчёте набора высоты.
+	elevationThreshold = 3.0
+)
+
+var (
+	ErrInvalidTrail = errors.New("invalid trail")
+	// ErrTrailExists - тропа с таким же slug уже загружена.
+	ErrTrailExists = repository.ErrTrailExists
+)
+
+type Trail struct {
+	repo     repository.Trail
+	landmark repository.Landmark
+}
+
+func NewTrailService(trail repository.Trail, landmark repository.Landmark) *Trail {
+	return &Trail{repo: trail, landmark: landmark}
+}
+
+// ImportGPX создаёт тропу по треку или маршруту из GPX-файла.
+func (s *Trail) ImportGPX(data []byte, meta models.TrailImport) (models.Trail, error) {
+	points, gpxName, err := parseGPX(data)
+	if err != nil {
+		return models.Trail{}, fmt.Errorf("%w: %v", ErrInvalidTrail, err)
+	}
+	if len(points) < 2 {
+		return models.Trail{}, fmt.Errorf("%w: track must contain at least 2 points", ErrInvalidTrail)
+	}
+	name := strings.TrimSpace(meta.Name)
+	if name == "" {
+		name = strings.TrimSpace(gpxName)
+	}
+	if name == "" {
+		return models.Trail{}, fmt.Errorf("%w: name is required", ErrInvalidTrail)
+	}
+
+	trail := models.Trail{
+		Name:          name,
+		Slug:          utils.Slugify(name),
+		Description:   meta.Description,
+		Length:        trackLength(points),
+		ElevationGain: elevationGain(points),
+	}
+	trail.Duration = hikingDuration(trail.Length, trail.ElevationGain)
+	switch meta.Difficulty {
+	case "":
+		trail.Difficulty = trailDifficulty(trail.Length, trail.ElevationGain)
+	case models.TrailDifficultyEasy, models.TrailDifficultyModerate, models.TrailDifficultyHard:
+		trail.Difficulty = meta.Difficulty
+	default:
+		return models.Trail{}, fmt.Errorf("%w: unknown difficulty %q", ErrInvalidTrail, meta.Difficulty)
+	}
+
+	path := make([]models.Location, len(points))
+	for i, p := range points {
+		path[i] = p.Location
+	}
+	trail.ID, err = s.repo.AddTrail(trail, path)
+	if err != nil {
+		return models.Trail{}, err
+	}
+	return s.repo.GetTrailBySlug(trail.Slug)
+}
+
+func (s *Trail) GetTrail(slug string) (models.Trail, error) {
+	return s.repo.GetTrailBySlug(slug)
+}
+
+func (s *Trail) GetTrailsNearLandmark(name string, radius float64) ([]models.Trail, error) {
+	if radius <= 0 {
+		radius = DefaultTrailRadius
+	}
+	radius = min(radius, MaxTrailRadius)
+	landmark, err := s.landmark.GetLandmarksByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetTrailsNearLandmark(landmark.ID, radius)
+}
+
+func (s *Trail) GetTrailsInBBOX(bbox models.BBOX) ([]models.Trail, error) {
+	return s.repo.GetTrailsInBBOX(bbox)
+}
+
+type gpxFile struct {
+	Metadata struct {
+		Name string `xml:"name"`
+	} `xml:"metadata"`
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Name   string     `xml:"name"`
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+type gpxPoint struct {
+	Lat float64  `xml:"lat,attr"`
+	Lon float64  `xml:"lon,attr"`
+	Ele *float64 `xml:"ele"`
+}
+
+// parseGPX возвращает точки всех треков GPX-файла (или маршрутов, если треков нет) и название.
+func parseGPX(data []byte) ([]models.TrackPoint, string, error) {
+	var gpx gpxFile
+	if err := xml.Unmarshal(data, &gpx); err != nil {
+		return nil, "", fmt.Errorf("failed to parse gpx: %w", err)
+	}
+	name := gpx.Metadata.Name
+	var raw []gpxPoint
+	for _, trk := range gpx.Tracks {
+		if name == "" {
+			name = trk.Name
+		}
+		for _, seg := range trk.Segments {
+			raw = append(raw, seg.Points...)
+		}
+	}
+	if len(raw) == 0 {
+		for _, rte := range gpx.Routes {
+			if name == "" {
+				name = rte.Name
+			}
+			raw = append(raw, rte.Points...)
+		}
+	}
+	points := make([]models.TrackPoint, 0, len(raw))
+	for _, p := range raw {
+		if p.Lat < -90 || p.Lat > 90 || p.Lon < -180 || p.Lon > 180 {
+			return nil, "", fmt.Errorf("invalid point %f,%f", p.Lat, p.Lon)
+		}
+		points = append(points, models.TrackPoint{Location: models.Location{Lat: p.Lat, Lng: p.Lon}, Elevation: p.Ele})
+	}
+	return points, name, nil
+}
+
+func trackLength(points []models.TrackPoint) float64 {
+	length := 0.0
+	for i := 1; i < len(points); i++ {
+		length += utils.Haversine(points[i-1].Location, points[i].Location)
+	}
+	return length
+}
+
+// elevationGain суммирует подъёмы, игнорируя колебания высоты меньше elevationThreshold.
+func elevationGain(points []models.TrackPoint) float64 {
+	gain := 0.0
+	var ref *float64
+	for _, p := range points {
+		if p.Elevation == nil {
+			continue
+		}
+		ele := *p.Elevation
+		switch {
+		case ref == nil:
+			ref = &ele
+		case ele-*ref >= elevationThreshold:
+			gain += ele - *ref
+			ref = &ele
+		case *ref-ele >= elevationThreshold:
+			ref = &ele
+		}
+	}
+	return gain
+}
+
+// hikingDuration оценивает время прохождения в минутах по правилу Нейсмита:
+// 5 км/ч по горизонтали и дополнительный час на каждые 600 м подъёма.
+func hikingDuration(length, gain float64) int {
+	hours := length/5000 + gain/600
+	return int(math.Round(hours * 60))
+}
+
+func trailDifficulty(length, gain float64) string {
+	score := length/1000 + gain/100
+	switch {
+	case score < 8:
+		return models.TrailDifficultyEasy
+	case score < 16:
+		return models.TrailDifficultyModerate
+	default:
+		return models.TrailDifficultyHard
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"testing"
+
+	"trailblazer/internal/models"
+)
+
+func TestParseGPX(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantPoints int
+		wantName   string
+		wantEle    bool
+		wantErr    bool
+	}{
+		{
+			name: "track with metadata name",
+			data: `<gpx><metadata><name>Тропа Голицына</name></metadata>
+				<trk><name>Трек</name><trkseg>
+					<trkpt lat="44.83" lon="34.96"><ele>12.5</ele></trkpt>
+					<trkpt lat="44.84" lon="34.97"><ele>40</ele></trkpt>
+				</trkseg><trkseg>
+					<trkpt lat="44.85" lon="34.98"><ele>55</ele></trkpt>
+				</trkseg></trk></gpx>`,
+			wantPoints: 3,
+			wantName:   "Тропа Голицына",
+			wantEle:    true,
+		},
+		{
+			name: "track name without metadata",
+			data: `<gpx><trk><name>Боткинская тропа</name><trkseg>
+					<trkpt lat="44.5" lon="34.1"/><trkpt lat="44.51" lon="34.11"/>
+				</trkseg></trk></gpx>`,
+			wantPoints: 2,
+			wantName:   "Боткинская тропа",
+		},
+		{
+			name: "route when there are no tracks",
+			data: `<gpx><rte><name>Маршрут</name>
+					<rtept lat="44.5" lon="34.1"/><rtept lat="44.51" lon="34.11"/><rtept lat="44.52" lon="34.12"/>
+				</rte></gpx>`,
+			wantPoints: 3,
+			wantName:   "Маршрут",
+		},
+		{
+			name:    "broken xml",
+			data:    `<gpx><trk>`,
+			wantErr: true,
+		},
+		{
+			name:    "latitude out of range",
+			data:    `<gpx><trk><trkseg><trkpt lat="91" lon="34.1"/></trkseg></trk></gpx>`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, name, err := parseGPX([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseGPX() = %d points, want error", len(points))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGPX() error = %v", err)
+			}
+			if len(points) != tt.wantPoints {
+				t.Errorf("parseGPX() = %d points, want %d", len(points), tt.wantPoints)
+			}
+			if name != tt.wantName {
+				t.Errorf("parseGPX() name = %q, want %q", name, tt.wantName)
+			}
+			if hasEle := points[0].Elevation != nil; hasEle != tt.wantEle {
+				t.Errorf("first point has elevation = %v, want %v", hasEle, tt.wantEle)
+			}
+		})
+	}
+}
+
+func TestElevationGain(t *testing.T) {
+	ele := func(values ...float64) []models.TrackPoint {
+		points := make([]models.TrackPoint, len(values))
+		for i := range values {
+			points[i].Elevation = &values[i]
+		}
+		return points
+	}
+	tests := []struct {
+		name   string
+		points []models.TrackPoint
+		want   float64
+	}{
+		{"no elevation", make([]models.TrackPoint, 3), 0},
+		{"steady climb", ele(100, 150, 220), 120},
+		{"gps noise ignored", ele(100, 101, 99, 102, 100), 0},
+		{"climb after descent", ele(100, 200, 150, 250), 200},
+		{"small steps counted from reference", ele(100, 102, 104, 106), 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := elevationGain(tt.points); got != tt.want {
+				t.Errorf("elevationGain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHikingDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		length float64
+		gain   float64
+		want   int
+	}{
+		{"flat 5 km", 5000, 0, 60},
+		{"flat 7.5 km", 7500, 0, 90},
+		{"600 m climb only", 0, 600, 60},
+		{"10 km with 300 m climb", 10000, 300, 150},
+		{"rounds to minutes", 1234, 0, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hikingDuration(tt.length, tt.gain); got != tt.want {
+				t.Errorf("hikingDuration(%v, %v) = %d, want %d", tt.length, tt.gain, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrailDifficulty(t *testing.T) {
+	tests := []struct {
+		length float64
+		gain   float64
+		want   string
+	}{
+		{3000, 100, models.TrailDifficultyEasy},
+		{7000, 200, models.TrailDifficultyModerate},
+		{12000, 600, models.TrailDifficultyHard},
+	}
+	for _, tt := range tests {
+		if got := trailDifficulty(tt.length, tt.gain); got != tt.want {
+			t.Errorf("trailDifficulty(%v, %v) = %s, want %s", tt.length, tt.gain, got, tt.want)
+		}
+	}
+}
@@ -34,6 +34,9 @@ func (s *User) AddReview(review models.Review) error {
 func (s *User) GetReview(name string, onlyPhoto bool) (map[int]models.ReviewByUser, error) {
 	return s.repo.GetReview(name, onlyPhoto)
 }
+func (s *User) IsAdmin(c context.Context, userID int64) (bool, error) {
+	return s.repo.IsAdmin(c, userID)
+}
 func (s *User) UpdateUserProfile(c context.Context, i int, username string, bytes []byte, bio string) error {
 	return s.repo.UpdateUserProfile(c, i, username, bytes, bio)
 }
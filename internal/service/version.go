@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"trailblazer/internal/repository"
+)
+
+// dataVersionTTL - как долго версия данных берётся из памяти без запроса к журналу.
+const dataVersionTTL = 5 * time.Second
+
+// dataVersion отслеживает версию данных о достопримечательностях по журналу
+// sync_changes. Дисковые кэши тайлов и пакетов хранятся в каталогах этой версии,
+// поэтому любое изменение в базе, в том числе с других экземпляров, сбрасывает их.
+type dataVersion struct {
+	repo repository.Sync
+
+	mu        sync.Mutex
+	value     string
+	checkedAt time.Time
+}
+
+func newDataVersion(repo repository.Sync) *dataVersion {
+	return &dataVersion{repo: repo}
+}
+
+// Current возвращает текущую версию или пустую строку, если её не удалось узнать.
+func (v *dataVersion) Current() string {
+	if v == nil || v.repo == nil {
+		return ""
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.value != "" && time.Since(v.checkedAt) < dataVersionTTL {
+		return v.value
+	}
+	value, err := v.repo.GetVersion()
+	if err != nil {
+		slog.Error(fmt.Sprintf("failed to get data version: %v", err))
+		return ""
+	}
+	v.value = value
+	v.checkedAt = time.Now()
+	return value
+}
+
+// removeStaleVersions удаляет из dir каталоги всех версий, кроме keep.
+func removeStaleVersions(dir, keep string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != keep {
+			if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+				slog.Error(fmt.Sprintf("failed to remove stale cache %s: %v", e.Name(), err))
+			}
+		}
+	}
+}
@@ -1,17 +1,40 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"trailblazer/internal/api"
 	"trailblazer/internal/config"
 	"trailblazer/internal/models"
 	"trailblazer/internal/repository"
 )
 
+// Значения по умолчанию для weather.grid_size и weather.workers.
+const (
+	DefaultWeatherGridSize = 0.05
+	DefaultWeatherWorkers  = 4
+)
+
+var (
+	ErrNoWeatherProvider = errors.New("weather provider is not configured")
+	// ErrWeatherRefreshFailed - не удалось обновить прогноз ни в одной ячейке сетки.
+	ErrWeatherRefreshFailed = errors.New("weather refresh failed for all cells")
+)
+
 type Weather struct {
-	repo repository.Weather
+	repo     repository.Weather
+	provider api.WeatherProvider
+	location *time.Location
 	config.WeatherConfig
 }
 
-func (w Weather) SetWeather(id int, forecast models.WeatherForecast) error {
+func (w Weather) SetWeather(id int, forecast models.LocationForecast) error {
 	return w.repo.SetWeather(id, forecast)
 }
 
@@ -19,9 +42,135 @@ func (w Weather) GetWeatherByLandmarkID(id int) (*[]models.WeatherResponse, erro
 	return w.repo.GetWeatherByLandmarkID(id)
 }
 
-func NewWeatherService(weather repository.Weather, weatherConfig config.WeatherConfig) Weather {
+func (w Weather) GetWeatherByLandmarkIDs(ids []int) (map[int][]models.WeatherResponse, error) {
+	if len(ids) == 0 {
+		return map[int][]models.WeatherResponse{}, nil
+	}
+	return w.repo.GetWeatherByLandmarkIDs(ids)
+}
+
+// weatherCell - ячейка сетки с достопримечательностями, для которых
+// запрашивается один общий прогноз.
+type weatherCell struct {
+	center    models.Location
+	landmarks []models.Landmark
+}
+
+// Refresh обновляет прогнозы для landmarks. Достопримечательности группируются
+// по ячейкам сетки GridSize, прогноз запрашивается в центре масс ячейки
+// не более чем Workers запросами одновременно. Отмена ctx прерывает обновление.
+func (w Weather) Refresh(ctx context.Context, landmarks []models.Landmark) (models.WeatherRefreshStats, error) {
+	stats := models.WeatherRefreshStats{StartedAt: time.Now(), Landmarks: len(landmarks)}
+	if w.provider == nil {
+		return stats, ErrNoWeatherProvider
+	}
+	cells := groupByCell(landmarks, w.GridSize)
+	stats.Cells = len(cells)
+
+	workers := w.Workers
+	if workers <= 0 {
+		workers = DefaultWeatherWorkers
+	}
+	jobs := make(chan weatherCell)
+	var lastErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range min(workers, max(len(cells), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for cell := range jobs {
+				updated, err := w.refreshCell(ctx, cell)
+				mu.Lock()
+				if err != nil {
+					stats.FailedCells++
+					lastErr = err
+				}
+				stats.Updated += updated
+				mu.Unlock()
+			}
+		}()
+	}
+dispatch:
+	for _, cell := range cells {
+		select {
+		case jobs <- cell:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return stats, err
+	}
+
+	stats.FinishedAt = time.Now()
+	slog.Info(fmt.Sprintf("weather refresh: %d landmarks in %d cells, %d failed cells, %d updated in %s",
+		stats.Landmarks, stats.Cells, stats.FailedCells, stats.Updated, stats.FinishedAt.Sub(stats.StartedAt)))
+	if stats.Cells > 0 && stats.FailedCells == stats.Cells {
+		return stats, fmt.Errorf("%w: %v", ErrWeatherRefreshFailed, lastErr)
+	}
+	return stats, nil
+}
+
+func (w Weather) refreshCell(ctx context.Context, cell weatherCell) (int, error) {
+	forecast, err := w.provider.Forecast(ctx, cell.center)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("failed to get weather at %v: %v", cell.center, err))
+		return 0, err
+	}
+	updated := 0
+	var saveErr error
+	for _, landmark := range cell.landmarks {
+		if err := w.repo.SetWeather(landmark.ID, forecast); err != nil {
+			slog.Error(fmt.Sprintf("failed to save weather for landmark %d: %v", landmark.ID, err))
+			saveErr = err
+			continue
+		}
+		updated++
+	}
+	if updated == 0 && saveErr != nil {
+		return 0, saveErr
+	}
+	return updated, nil
+}
+
+// groupByCell распределяет достопримечательности по ячейкам сетки size градусов.
+func groupByCell(landmarks []models.Landmark, size float64) []weatherCell {
+	if size <= 0 {
+		size = DefaultWeatherGridSize
+	}
+	type key struct{ lat, lng int }
+	index := make(map[key]int)
+	cells := make([]weatherCell, 0)
+	for _, l := range landmarks {
+		k := key{lat: int(math.Floor(l.Lat / size)), lng: int(math.Floor(l.Lng / size))}
+		i, ok := index[k]
+		if !ok {
+			i = len(cells)
+			index[k] = i
+			cells = append(cells, weatherCell{})
+		}
+		cells[i].landmarks = append(cells[i].landmarks, l)
+	}
+	for i := range cells {
+		var lat, lng float64
+		for _, l := range cells[i].landmarks {
+			lat += l.Lat
+			lng += l.Lng
+		}
+		n := float64(len(cells[i].landmarks))
+		cells[i].center = models.Location{Lat: lat / n, Lng: lng / n}
+	}
+	return cells
+}
+
+func NewWeatherService(weather repository.Weather, provider api.WeatherProvider, weatherConfig config.WeatherConfig) Weather {
 	return Weather{
 		repo:          weather,
+		provider:      provider,
+		location:      loadWeatherLocation(weatherConfig.Timezone),
 		WeatherConfig: weatherConfig,
 	}
 }
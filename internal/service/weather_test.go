@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"trailblazer/internal/config"
+	"trailblazer/internal/models"
+	"trailblazer/internal/repository"
+)
+
+func TestGroupByCell(t *testing.T) {
+	landmark := func(id int, lat, lng float64) models.Landmark {
+		return models.Landmark{ID: id, Location: models.Location{Lat: lat, Lng: lng}}
+	}
+	tests := []struct {
+		name      string
+		landmarks []models.Landmark
+		size      float64
+		// want - id достопримечательностей по ячейкам в порядке появления.
+		want    [][]int
+		centers []models.Location
+	}{
+		{
+			name:      "empty",
+			landmarks: nil,
+			size:      0.05,
+			want:      [][]int{},
+		},
+		{
+			name:      "same cell",
+			landmarks: []models.Landmark{landmark(1, 44.951, 34.101), landmark(2, 44.959, 34.109)},
+			size:      0.05,
+			want:      [][]int{{1, 2}},
+			centers:   []models.Location{{Lat: 44.955, Lng: 34.105}},
+		},
+		{
+			name:      "neighbouring cells",
+			landmarks: []models.Landmark{landmark(1, 44.951, 34.101), landmark(2, 44.49, 34.16), landmark(3, 44.952, 34.102)},
+			size:      0.05,
+			want:      [][]int{{1, 3}, {2}},
+			centers:   []models.Location{{Lat: 44.9515, Lng: 34.1015}, {Lat: 44.49, Lng: 34.16}},
+		},
+		{
+			name:      "cell border",
+			landmarks: []models.Landmark{landmark(1, 44.9499, 34.1), landmark(2, 44.9501, 34.1)},
+			size:      0.05,
+			want:      [][]int{{1}, {2}},
+		},
+		{
+			name:      "negative coordinates",
+			landmarks: []models.Landmark{landmark(1, -0.01, -0.01), landmark(2, 0.01, 0.01)},
+			size:      0.05,
+			want:      [][]int{{1}, {2}},
+		},
+		{
+			name:      "default size",
+			landmarks: []models.Landmark{landmark(1, 44.951, 34.101), landmark(2, 44.96, 34.11)},
+			size:      0,
+			want:      [][]int{{1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells := groupByCell(tt.landmarks, tt.size)
+			if len(cells) != len(tt.want) {
+				t.Fatalf("groupByCell() = %d cells, want %d", len(cells), len(tt.want))
+			}
+			for i, cell := range cells {
+				ids := make([]int, len(cell.landmarks))
+				for j, l := range cell.landmarks {
+					ids[j] = l.ID
+				}
+				if !equalInts(ids, tt.want[i]) {
+					t.Errorf("cell %d = %v, want %v", i, ids, tt.want[i])
+				}
+				if i < len(tt.centers) {
+					c := tt.centers[i]
+					if math.Abs(cell.center.Lat-c.Lat) > 1e-9 || math.Abs(cell.center.Lng-c.Lng) > 1e-9 {
+						t.Errorf("cell %d center = %v, want %v", i, cell.center, c)
+					}
+				}
+			}
+		})
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// fakeWeatherRepo реализует только используемые в тестах методы repository.Weather.
+type fakeWeatherRepo struct {
+	repository.Weather
+
+	mu      sync.Mutex
+	saved   map[int]models.LocationForecast
+	saveErr error
+}
+
+func (r *fakeWeatherRepo) SetWeather(id int, forecast models.LocationForecast) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	if r.saved == nil {
+		r.saved = make(map[int]models.LocationForecast)
+	}
+	r.saved[id] = forecast
+	return nil
+}
+
+// fakeProvider отвечает ошибкой для точек южнее failSouthOf.
+type fakeProvider struct {
+	failSouthOf float64
+	calls       int
+	mu          sync.Mutex
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Forecast(ctx context.Context, loc models.Location) (models.LocationForecast, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+	if loc.Lat < p.failSouthOf {
+		return models.LocationForecast{}, errors.New("provider unavailable")
+	}
+	return models.LocationForecast{Provider: p.Name()}, nil
+}
+
+func TestWeatherRefresh(t *testing.T) {
+	landmarks := []models.Landmark{
+		{ID: 1, Location: models.Location{Lat: 44.951, Lng: 34.101}},
+		{ID: 2, Location: models.Location{Lat: 44.952, Lng: 34.102}},
+		{ID: 3, Location: models.Location{Lat: 44.49, Lng: 34.16}},
+	}
+	tests := []struct {
+		name        string
+		failSouthOf float64
+		saveErr     error
+		wantStats   models.WeatherRefreshStats
+		wantErr     error
+	}{
+		{
+			name:      "all cells updated",
+			wantStats: models.WeatherRefreshStats{Landmarks: 3, Cells: 2, Updated: 3},
+		},
+		{
+			name:        "one cell failed",
+			failSouthOf: 44.5,
+			wantStats:   models.WeatherRefreshStats{Landmarks: 3, Cells: 2, FailedCells: 1, Updated: 2},
+		},
+		{
+			name:        "all cells failed",
+			failSouthOf: 90,
+			wantStats:   models.WeatherRefreshStats{Landmarks: 3, Cells: 2, FailedCells: 2},
+			wantErr:     ErrWeatherRefreshFailed,
+		},
+		{
+			name:      "saving failed",
+			saveErr:   errors.New("db is down"),
+			wantStats: models.WeatherRefreshStats{Landmarks: 3, Cells: 2, FailedCells: 2},
+			wantErr:   ErrWeatherRefreshFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{failSouthOf: tt.failSouthOf}
+			w := NewWeatherService(&fakeWeatherRepo{saveErr: tt.saveErr}, provider, config.WeatherConfig{GridSize: 0.05, Workers: 2})
+			stats, err := w.Refresh(context.Background(), landmarks)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Refresh() error = %v, want %v", err, tt.wantErr)
+			}
+			if stats.Landmarks != tt.wantStats.Landmarks || stats.Cells != tt.wantStats.Cells ||
+				stats.FailedCells != tt.wantStats.FailedCells || stats.Updated != tt.wantStats.Updated {
+				t.Errorf("Refresh() stats = %+v, want %+v", stats, tt.wantStats)
+			}
+			if provider.calls != 2 {
+				t.Errorf("provider called %d times, want one call per cell", provider.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestResizeJPEG(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int
+		maxSize      int
+		wantW, wantH int
+	}{
+		{"landscape", 400, 200, 100, 100, 50},
+		{"portrait", 200, 400, 100, 50, 100},
+		{"square", 300, 300, 128, 128, 128},
+		{"thin strip", 1000, 2, 100, 100, 1},
+		{"smaller than max", 80, 60, 100, 80, 60},
+		{"no limit", 400, 200, 0, 400, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ResizeJPEG(testPNG(t, tt.w, tt.h), tt.maxSize, 75)
+			if err != nil {
+				t.Fatalf("ResizeJPEG() error = %v", err)
+			}
+			img, err := jpeg.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("result is not a JPEG: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Errorf("ResizeJPEG() size = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestResizeJPEGInvalid(t *testing.T) {
+	if _, err := ResizeJPEG([]byte("not an image"), 100, 75); err == nil {
+		t.Error("ResizeJPEG() error = nil, want decode error")
+	}
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// Реализация стеммера Snowball для русского языка
+// (https://snowballstem.org/algorithms/russian/stemmer.html).
+
+var (
+	perfectiveGerund1 = sortedEndings("в", "вши", "вшись")
+	perfectiveGerund2 = sortedEndings("ив", "ивши", "ившись", "ыв", "ывши", "ывшись")
+	adjectiveEndings  = sortedEndings("ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею")
+	participle1     = sortedEndings("ем", "нн", "вш", "ющ", "щ")
+	participle2     = sortedEndings("ивш", "ывш", "ующ")
+	reflexiveEnding = sortedEndings("ся", "сь")
+	verb1           = sortedEndings("ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно")
+	verb2           = sortedEndings("ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю")
+	nounEndings = sortedEndings("а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+		"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я")
+	superlativeEndings   = sortedEndings("ейше", "ейш")
+	derivationalEndings  = sortedEndings("ость", "ост")
+	russianVowels        = "аеиоуыэюя"
+	perfectivePrecedents = "ая"
+)
+
+func sortedEndings(endings ...string) [][]rune {
+	res := make([][]rune, len(endings))
+	for i, e := range endings {
+		res[i] = []rune(e)
+	}
+	sort.Slice(res, func(i, j int) bool { return len(res[i]) > len(res[j]) })
+	return res
+}
+
+// StemRussian возвращает основу русского слова. Слово должно быть в нижнем регистре.
+func StemRussian(word string) string {
+	w := []rune(strings.ReplaceAll(word, "ё", "е"))
+	rv, r2 := russianRegions(w)
+
+	// Шаг 1.
+	if n := matchEnding(w, rv, perfectiveGerund1, true); n > 0 {
+		w = w[:len(w)-n]
+	} else if n := matchEnding(w, rv, perfectiveGerund2, false); n > 0 {
+		w = w[:len(w)-n]
+	} else {
+		if n := matchEnding(w, rv, reflexiveEnding, false); n > 0 {
+			w = w[:len(w)-n]
+		}
+		if n := matchEnding(w, rv, adjectiveEndings, false); n > 0 {
+			w = w[:len(w)-n]
+			if n := matchEnding(w, rv, participle1, true); n > 0 {
+				w = w[:len(w)-n]
+			} else if n := matchEnding(w, rv, participle2, false); n > 0 {
+				w = w[:len(w)-n]
+			}
+		} else if n := max(matchEnding(w, rv, verb1, true), matchEnding(w, rv, verb2, false)); n > 0 {
+			w = w[:len(w)-n]
+		} else if n := matchEnding(w, rv, nounEndings, false); n > 0 {
+			w = w[:len(w)-n]
+		}
+	}
+
+	// Шаг 2.
+	if len(w) > rv && w[len(w)-1] == 'и' {
+		w = w[:len(w)-1]
+	}
+
+	// Шаг 3.
+	if n := matchEnding(w, r2, derivationalEndings, false); n > 0 {
+		w = w[:len(w)-n]
+	}
+
+	// Шаг 4.
+	if n := matchEnding(w, rv, superlativeEndings, false); n > 0 {
+		w = w[:len(w)-n]
+	}
+	if len(w)-2 >= rv && w[len(w)-1] == 'н' && w[len(w)-2] == 'н' {
+		w = w[:len(w)-1]
+	} else if len(w) > rv && w[len(w)-1] == 'ь' {
+		w = w[:len(w)-1]
+	}
+	return string(w)
+}
+
+// russianRegions возвращает начало областей RV и R2.
+func russianRegions(w []rune) (int, int) {
+	isVowel := func(r rune) bool { return strings.ContainsRune(russianVowels, r) }
+	rv, r1, r2 := len(w), len(w), len(w)
+	for i, r := range w {
+		if isVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+	for i := 1; i < len(w); i++ {
+		if !isVowel(w[i]) && isVowel(w[i-1]) {
+			r1 = i + 1
+			break
+		}
+	}
+	for i := r1 + 1; i < len(w); i++ {
+		if !isVowel(w[i]) && isVowel(w[i-1]) {
+			r2 = i + 1
+			break
+		}
+	}
+	return rv, r2
+}
+
+// matchEnding возвращает длину самого длинного окончания из endings, целиком
+// лежащего в области, начинающейся с позиции start. Если afterAYa, окончанию
+// должна предшествовать «а» или «я», также лежащая в этой области.
+func matchEnding(w []rune, start int, endings [][]rune, afterAYa bool) int {
+	for _, e := range endings {
+		p := len(w) - len(e)
+		if p < start || string(w[p:]) != string(e) {
+			continue
+		}
+		if afterAYa && (p-1 < start || !strings.ContainsRune(perfectivePrecedents, w[p-1])) {
+			continue
+		}
+		return len(e)
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package utils
+
+import "testing"
+
+// Ожидаемые основы взяты из эталонного словаря Snowball для русского языка.
+func TestStemRussian(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"в", "в"},
+		{"вавиловка", "вавиловк"},
+		{"вагнера", "вагнер"},
+		{"вагоне", "вагон"},
+		{"вагонов", "вагон"},
+		{"вагоном", "вагон"},
+		{"важная", "важн"},
+		{"важнее", "важн"},
+		{"важнейшие", "важн"},
+		{"важнейшим", "важн"},
+		{"важничает", "важнича"},
+		{"важности", "важност"},
+		{"важностию", "важност"},
+		{"важную", "важн"},
+		{"вазах", "ваз"},
+		{"валандался", "валанда"},
+		{"валерьяновых", "валерьянов"},
+		{"валился", "вал"},
+		{"вальдшнепа", "вальдшнеп"},
+		{"валяется", "валя"},
+		{"валялась", "валя"},
+		{"валять", "валя"},
+		{"валяются", "валя"},
+		{"вами", "вам"},
+		{"зелёный", "зелен"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := StemRussian(tt.word); got != tt.want {
+				t.Errorf("StemRussian(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,7 @@ package utils
 
 import (
 	"fmt"
-	"strconv"
+	"math"
 	"strings"
 	"time"
 
@@ -11,21 +11,9 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/mozillazg/go-unidecode"
 )
 
-func LocationFromPoint(p string) models.Location {
-	p = strings.TrimPrefix(p, "POINT(")
-	p = strings.TrimSuffix(p, ")")
-	numbers := strings.Fields(p)
-	lon, _ := strconv.ParseFloat(numbers[0], 64)
-	lat, _ := strconv.ParseFloat(numbers[1], 64)
-	loc := models.Location{
-		Lat: lat,
-		Lng: lon,
-	}
-	return loc
-}
-
 type Hasher interface {
 	HashPassword(password string) (string, error)
 	CheckPassword(hashedPassword, password string) bool
@@ -128,3 +116,69 @@ func convertClaimsToPayload(claims jwt.MapClaims) (*Payload, error) {
 		IssuedAt:  time.Unix(int64(iat), 0),
 	}, nil
 }
+
+const earthRadius = 6371008.8
+
+// Haversine возвращает расстояние по дуге большого круга между двумя точками в метрах.
+func Haversine(a, b models.Location) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Slugify транслитерирует строку и приводит её к виду, пригодному для URL.
+func Slugify(s string) string {
+	s = strings.ToLower(unidecode.Unidecode(s))
+	var b strings.Builder
+	underscore := false
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// DecodePolyline декодирует строку в формате Encoded Polyline Algorithm
+// с заданным числом знаков после запятой (5 для Google, 6 для OSRM/Valhalla).
+func DecodePolyline(encoded string, precision int) ([]models.Location, error) {
+	factor := math.Pow10(precision)
+	var points []models.Location
+	var lat, lng int
+	for i := 0; i < len(encoded); {
+		var deltas [2]int
+		for k := range deltas {
+			result, shift := 0, 0
+			for {
+				if i >= len(encoded) {
+					return nil, fmt.Errorf("invalid polyline: unexpected end")
+				}
+				b := int(encoded[i]) - 63
+				i++
+				if b < 0 || b > 63 {
+					return nil, fmt.Errorf("invalid polyline: bad character at %d", i-1)
+				}
+				result |= (b & 0x1f) << shift
+				shift += 5
+				if b < 0x20 {
+					break
+				}
+			}
+			if result&1 != 0 {
+				deltas[k] = ^(result >> 1)
+			} else {
+				deltas[k] = result >> 1
+			}
+		}
+		lat += deltas[0]
+		lng += deltas[1]
+		points = append(points, models.Location{Lat: float64(lat) / factor, Lng: float64(lng) / factor})
+	}
+	return points, nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"math"
+	"testing"
+
+	"trailblazer/internal/models"
+)
+
+func TestDecodePolyline(t *testing.T) {
+	// Пример из описания формата Google Encoded Polyline.
+	const google = "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+	tests := []struct {
+		name      string
+		encoded   string
+		precision int
+		want      []models.Location
+		wantErr   bool
+	}{
+		{
+			name:      "google example",
+			encoded:   google,
+			precision: 5,
+			want:      []models.Location{{Lat: 38.5, Lng: -120.2}, {Lat: 40.7, Lng: -120.95}, {Lat: 43.252, Lng: -126.453}},
+		},
+		{
+			name:      "precision 6",
+			encoded:   google,
+			precision: 6,
+			want:      []models.Location{{Lat: 3.85, Lng: -12.02}, {Lat: 4.07, Lng: -12.095}, {Lat: 4.3252, Lng: -12.6453}},
+		},
+		{name: "empty", encoded: "", precision: 5},
+		{name: "missing longitude", encoded: "_p~iF", precision: 5, wantErr: true},
+		{name: "truncated chunk", encoded: "_p~i", precision: 5, wantErr: true},
+		{name: "bad character", encoded: "_p~iF~ps| ", precision: 5, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePolyline(tt.encoded, tt.precision)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DecodePolyline() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodePolyline() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("DecodePolyline() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i].Lat-tt.want[i].Lat) > 1e-9 || math.Abs(got[i].Lng-tt.want[i].Lng) > 1e-9 {
+					t.Errorf("point %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
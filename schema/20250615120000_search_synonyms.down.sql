@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS search_synonyms;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,14 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS search_synonyms(
+    id SERIAL PRIMARY KEY,
+    term VARCHAR(150) NOT NULL UNIQUE,
+    synonyms TEXT[] NOT NULL DEFAULT '{}',
+    updated_at timestamptz DEFAULT current_timestamp
+);
+
+INSERT INTO search_synonyms(term, synonyms)
+VALUES ('херсонес', '{"херсонес таврический"}'),
+       ('ханский дворец', '{"бахчисарайский дворец"}'),
+       ('ай-петри', '{"ай петри"}')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS trails(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    slug VARCHAR(150) NOT NULL UNIQUE,
+    description TEXT,
+    difficulty VARCHAR(20) NOT NULL,
+    length float NOT NULL,
+    elevation_gain float NOT NULL DEFAULT 0,
+    duration int NOT NULL,
+    path geography(LINESTRING, 4326) NOT NULL,
+    created_at timestamptz DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_trails_path ON trails USING gist(path);
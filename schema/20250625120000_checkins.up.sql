@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS checkins(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    landmark_id INT NOT NULL REFERENCES landmark(id) ON DELETE CASCADE,
+    location geography(POINT, 4326) NOT NULL,
+    distance float NOT NULL,
+    created_at timestamptz DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_checkins_user ON checkins(user_id, created_at DESC);
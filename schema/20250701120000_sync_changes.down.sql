@@ -0,0 +1,10 @@
+ALTER TABLE weather DROP CONSTRAINT IF EXISTS weather_landmark_id_fkey;
+ALTER TABLE weather ADD CONSTRAINT weather_landmark_id_fkey
+    FOREIGN KEY (landmark_id) REFERENCES landmark(id);
+DROP TRIGGER IF EXISTS landmark_changes_update ON landmark;
+DROP TRIGGER IF EXISTS landmark_changes_insert_delete ON landmark;
+DROP TRIGGER IF EXISTS landmark_updated_at ON landmark;
+DROP FUNCTION IF EXISTS landmark_track_changes();
+DROP FUNCTION IF EXISTS landmark_set_updated_at();
+DROP TABLE IF EXISTS sync_changes;
+ALTER TABLE landmark DROP COLUMN IF EXISTS updated_at;
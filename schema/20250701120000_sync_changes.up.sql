@@ -0,0 +1,98 @@
+ALTER TABLE landmark ADD COLUMN IF NOT EXISTS images_name text;
+ALTER TABLE landmark ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT current_timestamp;
+
+-- id выдаётся при вставке, а не при фиксации транзакции, поэтому курсор
+-- строится по номеру транзакции txid: клиенту отдаются только записи
+-- транзакций, завершившихся до начала самой старой из выполняющихся.
+CREATE TABLE IF NOT EXISTS sync_changes(
+    id BIGSERIAL PRIMARY KEY,
+    txid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+    entity VARCHAR(20) NOT NULL,
+    entity_key TEXT NOT NULL,
+    op VARCHAR(10) NOT NULL,
+    changed_at timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS sync_changes_txid_idx ON sync_changes(txid, id);
+
+ALTER TABLE weather DROP CONSTRAINT IF EXISTS weather_landmark_id_fkey;
+ALTER TABLE weather ADD CONSTRAINT weather_landmark_id_fkey
+    FOREIGN KEY (landmark_id) REFERENCES landmark(id) ON DELETE CASCADE;
+
+CREATE OR REPLACE FUNCTION landmark_set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = current_timestamp;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION landmark_track_changes() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO sync_changes(entity, entity_key, op) VALUES ('landmark', NEW.id::text, 'insert');
+        IF NEW.images_name IS NOT NULL THEN
+            INSERT INTO sync_changes(entity, entity_key, op) VALUES ('image', NEW.images_name, 'insert');
+        END IF;
+        IF NEW.category IS NOT NULL AND NOT EXISTS (SELECT 1 FROM landmark WHERE category = NEW.category AND id <> NEW.id) THEN
+            INSERT INTO sync_changes(entity, entity_key, op) VALUES ('category', NEW.category, 'insert');
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        INSERT INTO sync_changes(entity, entity_key, op) VALUES ('landmark', NEW.id::text, 'update');
+        IF NEW.images_name IS DISTINCT FROM OLD.images_name THEN
+            IF OLD.images_name IS NOT NULL THEN
+                INSERT INTO sync_changes(entity, entity_key, op) VALUES ('image', OLD.images_name, 'delete');
+            END IF;
+            IF NEW.images_name IS NOT NULL THEN
+                INSERT INTO sync_changes(entity, entity_key, op) VALUES ('image', NEW.images_name, 'insert');
+            END IF;
+        END IF;
+        IF NEW.category IS DISTINCT FROM OLD.category THEN
+            IF OLD.category IS NOT NULL AND NOT EXISTS (SELECT 1 FROM landmark WHERE category = OLD.category) THEN
+                INSERT INTO sync_changes(entity, entity_key, op) VALUES ('category', OLD.category, 'delete');
+            END IF;
+            IF NEW.category IS NOT NULL AND NOT EXISTS (SELECT 1 FROM landmark WHERE category = NEW.category AND id <> NEW.id) THEN
+                INSERT INTO sync_changes(entity, entity_key, op) VALUES ('category', NEW.category, 'insert');
+            END IF;
+        END IF;
+        RETURN NEW;
+    ELSE
+        INSERT INTO sync_changes(entity, entity_key, op) VALUES ('landmark', OLD.id::text, 'delete');
+        IF OLD.images_name IS NOT NULL THEN
+            INSERT INTO sync_changes(entity, entity_key, op) VALUES ('image', OLD.images_name, 'delete');
+        END IF;
+        IF OLD.category IS NOT NULL AND NOT EXISTS (SELECT 1 FROM landmark WHERE category = OLD.category) THEN
+            INSERT INTO sync_changes(entity, entity_key, op) VALUES ('category', OLD.category, 'delete');
+        END IF;
+        RETURN OLD;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER landmark_updated_at
+    BEFORE UPDATE ON landmark
+    FOR EACH ROW
+    WHEN (OLD.* IS DISTINCT FROM NEW.*)
+    EXECUTE FUNCTION landmark_set_updated_at();
+
+CREATE TRIGGER landmark_changes_insert_delete
+    AFTER INSERT OR DELETE ON landmark
+    FOR EACH ROW
+    EXECUTE FUNCTION landmark_track_changes();
+
+-- updated_at не меняется при повторном UPDATE в той же транзакции,
+-- поэтому изменение определяется по всей строке.
+CREATE TRIGGER landmark_changes_update
+    AFTER UPDATE ON landmark
+    FOR EACH ROW
+    WHEN (OLD.* IS DISTINCT FROM NEW.*)
+    EXECUTE FUNCTION landmark_track_changes();
+
+INSERT INTO sync_changes(entity, entity_key, op)
+SELECT 'landmark', id::text, 'insert' FROM landmark ORDER BY id;
+
+INSERT INTO sync_changes(entity, entity_key, op)
+SELECT DISTINCT 'image', images_name, 'insert' FROM landmark WHERE images_name IS NOT NULL;
+
+INSERT INTO sync_changes(entity, entity_key, op)
+SELECT DISTINCT 'category', category, 'insert' FROM landmark WHERE category IS NOT NULL;
@@ -0,0 +1,9 @@
+ALTER TABLE weather
+    DROP COLUMN IF EXISTS feels_like,
+    DROP COLUMN IF EXISTS humidity,
+    DROP COLUMN IF EXISTS pressure,
+    DROP COLUMN IF EXISTS pop,
+    DROP COLUMN IF EXISTS clouds,
+    DROP COLUMN IF EXISTS wind_gust,
+    DROP COLUMN IF EXISTS visibility,
+    DROP COLUMN IF EXISTS pod;
@@ -0,0 +1,9 @@
+ALTER TABLE weather
+    ADD COLUMN IF NOT EXISTS feels_like float NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS humidity int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS pressure int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS pop float NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS clouds int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS wind_gust float NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS visibility int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS pod varchar(1) NOT NULL DEFAULT '';
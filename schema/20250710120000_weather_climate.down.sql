@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS weather_date_idx;
+DROP TABLE IF EXISTS weather_climate;
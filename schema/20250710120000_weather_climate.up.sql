@@ -0,0 +1,17 @@
+-- Суммы по прошедшим прогнозам: средние считаются при чтении, поэтому новые
+-- данные можно добавлять к уже накопленным.
+CREATE TABLE IF NOT EXISTS weather_climate(
+    landmark_id INT NOT NULL REFERENCES landmark(id) ON DELETE CASCADE,
+    month SMALLINT NOT NULL CHECK (month BETWEEN 1 AND 12),
+    slots INT NOT NULL DEFAULT 0,
+    temp_sum float NOT NULL DEFAULT 0,
+    wind_sum float NOT NULL DEFAULT 0,
+    days INT NOT NULL DEFAULT 0,
+    high_sum float NOT NULL DEFAULT 0,
+    low_sum float NOT NULL DEFAULT 0,
+    rain_sum float NOT NULL DEFAULT 0,
+    rainy_days INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (landmark_id, month)
+);
+
+CREATE INDEX IF NOT EXISTS weather_date_idx ON weather(date);